@@ -0,0 +1,10 @@
+data "propel_data_pool" "my_data_pool" {
+  unique_name = "My Data Pool"
+}
+
+resource "propel_metric" "my_count_metric" {
+  unique_name = "my_count_metric"
+  data_pool   = data.propel_data_pool.my_data_pool.id
+
+  type = "COUNT"
+}
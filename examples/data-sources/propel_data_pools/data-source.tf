@@ -0,0 +1,12 @@
+data "propel_data_pools" "production" {
+  name_regex = "^prod-"
+}
+
+resource "propel_metric" "counts" {
+  for_each = { for data_pool in data.propel_data_pools.production.data_pools : data_pool.unique_name => data_pool }
+
+  unique_name = "${each.key}-count"
+  data_pool   = each.value.id
+
+  type = "COUNT"
+}
@@ -0,0 +1,3 @@
+data "propel_data_source" "my_data_source" {
+  unique_name = "My Snowflake Data Source"
+}
@@ -0,0 +1,4 @@
+data "propel_data_sources" "snowflake" {
+  type   = "SNOWFLAKE"
+  status = "CONNECTED"
+}
@@ -0,0 +1,3 @@
+data "propel_metric" "my_metric" {
+  id = "MET00000000000000000000000000"
+}
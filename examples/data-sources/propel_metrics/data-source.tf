@@ -0,0 +1,3 @@
+data "propel_metrics" "my_data_pool_metrics" {
+  data_pool = propel_data_pool.my_data_pool.id
+}
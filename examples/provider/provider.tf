@@ -13,4 +13,9 @@ provider "propel" {
 
   # Your Propel Application's secret.
   client_secret = var.propel_client_secret
+
+  # The Propel region. The API and OAuth URLs can also be set with
+  # api_url and oauth_url, or the PROPEL_API_URL and PROPEL_OAUTH_URL
+  # environment variables.
+  region = "us_east_2"
 }
@@ -0,0 +1,10 @@
+resource "propel_application" "my_application" {
+  unique_name = "My Application"
+  description = "This is an example of an Application"
+  propeller   = "P1_X_SMALL"
+  scopes      = ["METRIC_QUERY", "METRIC_STATS"]
+}
+
+output "my_application_client_id" {
+  value = propel_application.my_application.client_id
+}
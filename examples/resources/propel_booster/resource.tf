@@ -0,0 +1,8 @@
+resource "propel_booster" "my_booster" {
+  metric     = propel_metric.my_metric.id
+  dimensions = ["country", "store"]
+
+  timeouts {
+    create = "1h"
+  }
+}
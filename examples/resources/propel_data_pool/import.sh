@@ -1 +1,5 @@
+# Import by ID
 terraform import propel_data_pool.my_data_pool DPO00000000000000000000000000
+
+# Import by unique name
+terraform import propel_data_pool.my_data_pool "name:My Data Pool"
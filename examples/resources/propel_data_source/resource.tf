@@ -3,6 +3,11 @@ variable "snowflake_password" {
   sensitive = true
 }
 
+variable "redshift_password" {
+  type      = string
+  sensitive = true
+}
+
 resource "propel_data_source" "my_data_source" {
   unique_name = "My Snowflake Data Source"
   description = "This is an example of a Snowflake Data Source"
@@ -18,3 +23,47 @@ resource "propel_data_source" "my_data_source" {
     password  = var.snowflake_password
   }
 }
+
+resource "propel_data_source" "my_bigquery_data_source" {
+  unique_name = "My BigQuery Data Source"
+  description = "This is an example of a BigQuery Data Source"
+  type        = "BIGQUERY"
+
+  bigquery_connection_settings {
+    credentials_json = file("service-account-key.json")
+    dataset_id       = "BigQuery Dataset ID"
+    project_id       = "GCP Project ID"
+  }
+}
+
+resource "propel_data_source" "my_redshift_data_source" {
+  unique_name = "My Redshift Data Source"
+  description = "This is an example of a Redshift Data Source"
+  type        = "Redshift"
+
+  redshift_connection_settings {
+    host           = "examplecluster.abc123xyz789.us-east-2.redshift.amazonaws.com"
+    port           = 5439
+    database       = "Redshift Database"
+    schema         = "Redshift Schema"
+    username       = "Redshift Username"
+    password       = var.redshift_password
+    aws_account_id = "123456789012"
+  }
+}
+
+data "aws_iam_policy_document" "propel_assume_role" {
+  statement {
+    actions = ["sts:AssumeRole"]
+
+    principals {
+      type        = "AWS"
+      identifiers = [propel_data_source.my_redshift_data_source.propel_role_arn]
+    }
+  }
+}
+
+resource "aws_iam_role" "propel_redshift" {
+  name               = "propel-redshift"
+  assume_role_policy = data.aws_iam_policy_document.propel_assume_role.json
+}
@@ -52,4 +52,21 @@ resource "propel_metric" "my_count_distinct_metric" {
   }
 
   dimensions = ["store"]
-}
\ No newline at end of file
+}
+
+resource "propel_metric" "my_average_metric" {
+  unique_name = "my_average_metric"
+  description = "This is an example of an Average Metric"
+  data_pool   = propel_data_pool.my_data_pool.id
+
+  type    = "AVERAGE"
+  measure = "price"
+
+  filter {
+    column   = "product_name"
+    operator = "EQUALS"
+    value    = "foo"
+  }
+
+  dimensions = ["store"]
+}
@@ -0,0 +1 @@
+terraform import propel_policy.my_policy POL00000000000000000000000000
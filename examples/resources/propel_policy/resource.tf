@@ -0,0 +1,5 @@
+resource "propel_policy" "my_policy" {
+  type        = "TENANT_ACCESS"
+  metric      = propel_metric.my_metric.id
+  application = propel_application.my_application.id
+}
@@ -0,0 +1,39 @@
+package propel
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+// columnsByName indexes the columns of a Data Source table or a Data Pool by their name.
+func columnsByName(columns []interface{}) map[string]map[string]interface{} {
+	byName := make(map[string]map[string]interface{}, len(columns))
+	for _, rawColumn := range columns {
+		column, ok := rawColumn.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		byName[column["name"].(string)] = column
+	}
+
+	return byName
+}
+
+// sameColumn returns whether two columns of the same name have the same type and nullability.
+func sameColumn(a, b map[string]interface{}) bool {
+	return a["type"] == b["type"] && a["nullable"] == b["nullable"]
+}
+
+// forceNewNested marks the resource for replacement because of the changes to a nested block. Forcing a new resource
+// on the block alone does not mark the changes to its nested attributes, so each one is forced as well.
+func forceNewNested(d *schema.ResourceDiff, key string) error {
+	for _, changed := range d.GetChangedKeysPrefix(key) {
+		if !d.HasChange(changed) {
+			continue
+		}
+
+		if err := d.ForceNew(changed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package propel
+
+import (
+	"context"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/propeldata/terraform-provider-propel/propel/internal/utils"
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+func dataSourceDataPool() *schema.Resource {
+	dsSchema := utils.DatasourceSchemaFromResourceSchema(resourceDataPool().Schema)
+
+	dsSchema["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "unique_name"},
+		Description:  "The Data Pool's ID.",
+	}
+	dsSchema["unique_name"].Optional = true
+	dsSchema["unique_name"].ExactlyOneOf = []string{"id", "unique_name"}
+
+	return &schema.Resource{
+		ReadContext: dataSourceDataPoolRead,
+		Description: "Provides a Propel Data Pool data source. This can be used to look up an existing Propel Data Pool by ID or unique name.",
+		Schema:      dsSchema,
+	}
+}
+
+func dataSourceDataPoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	if id, ok := d.GetOk("id"); ok {
+		d.SetId(id.(string))
+
+		return dataSourceDataPoolReadById(ctx, d, meta)
+	}
+
+	uniqueName := d.Get("unique_name").(string)
+
+	response, err := pc.DataPoolByName(ctx, c, uniqueName)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	if response.DataPool == nil {
+		return diag.Errorf("Data Pool %q not found", uniqueName)
+	}
+
+	d.SetId(response.DataPool.Id)
+
+	return dataSourceDataPoolReadById(ctx, d, meta)
+}
+
+// dataSourceDataPoolReadById reads the Data Pool with the ID in d, failing if it does not exist.
+func dataSourceDataPoolReadById(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	diags := resourceDataPoolRead(ctx, d, meta)
+	if !diags.HasError() && d.Id() == "" {
+		return diag.Errorf("Data Pool %q not found", id)
+	}
+
+	return diags
+}
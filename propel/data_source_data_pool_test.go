@@ -0,0 +1,40 @@
+package propel
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPropelDataPoolDataSourceBasic(t *testing.T) {
+	ctx := map[string]interface{}{}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckPropelDataPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPropelDataPoolDataSourceConfigBasic(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.propel_data_pool.by_id", "id", "propel_data_pool.bar", "id"),
+					resource.TestCheckResourceAttrPair("data.propel_data_pool.by_id", "table", "propel_data_pool.bar", "table"),
+					resource.TestCheckResourceAttrPair("data.propel_data_pool.by_name", "id", "propel_data_pool.bar", "id"),
+					resource.TestCheckResourceAttrPair("data.propel_data_pool.by_name", "data_source", "propel_data_pool.bar", "data_source"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPropelDataPoolDataSourceConfigBasic(ctx map[string]interface{}) string {
+	return testAccCheckPropelDataPoolConfigBasic(ctx) + `
+
+	data "propel_data_pool" "by_id" {
+		id = propel_data_pool.bar.id
+	}
+
+	data "propel_data_pool" "by_name" {
+		unique_name = propel_data_pool.bar.unique_name
+	}`
+}
@@ -0,0 +1,168 @@
+package propel
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/propeldata/terraform-provider-propel/propel/internal/utils"
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+func dataSourceDataPools() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDataPoolsRead,
+		Description: "Provides a list of Propel Data Pools. This can be used to look up every Data Pool in the Environment, optionally filtered by name, status or Data Source.",
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "A regular expression that the Data Pools' unique names must match.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return Data Pools with this status.",
+			},
+			"data_source": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return Data Pools that belong to this Data Source.",
+			},
+			"data_pools": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Data Pools that match the filters.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Data Pool's ID.",
+						},
+						"unique_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Data Pool's name.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Data Pool's description.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Data Pool's status.",
+						},
+						"data_source": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Data Source that the Data Pool belongs to.",
+						},
+						"table": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the Data Pool's table.",
+						},
+						"timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Data Pool's timestamp column.",
+						},
+						"account": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Account that the Data Pool belongs to.",
+						},
+						"environment": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Environment that the Data Pool belongs to.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDataPoolsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	var diags diag.Diagnostics
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	status := d.Get("status").(string)
+	dataSourceId := d.Get("data_source").(string)
+
+	dataPools := make([]map[string]interface{}, 0)
+	ids := make([]string, 0)
+
+	first := pc.PageSize
+	var after *string
+
+	for {
+		response, err := pc.DataPools(ctx, c, &first, nil, after, nil)
+		if err != nil {
+			return diagFromErr(err)
+		}
+
+		if response.DataPools == nil {
+			break
+		}
+
+		for _, edge := range response.DataPools.Edges {
+			dataPool := edge.Node
+
+			if nameRegex != nil && !nameRegex.MatchString(dataPool.UniqueName) {
+				continue
+			}
+
+			if status != "" && !strings.EqualFold(string(dataPool.Status), status) {
+				continue
+			}
+
+			if dataSourceId != "" && dataPool.DataSource.Id != dataSourceId {
+				continue
+			}
+
+			dataPools = append(dataPools, map[string]interface{}{
+				"id":          dataPool.Id,
+				"unique_name": dataPool.UniqueName,
+				"description": dataPool.Description,
+				"status":      string(dataPool.Status),
+				"data_source": dataPool.DataSource.Id,
+				"table":       dataPool.Table,
+				"timestamp":   dataPool.Timestamp.ColumnName,
+				"account":     dataPool.Account.Id,
+				"environment": dataPool.Environment.Id,
+			})
+			ids = append(ids, dataPool.Id)
+		}
+
+		pageInfo := response.DataPools.PageInfo
+		if pageInfo == nil || !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			break
+		}
+
+		after = pageInfo.EndCursor
+	}
+
+	d.SetId(utils.DatasourceIdFromIds(ids))
+	if err := d.Set("data_pools", dataPools); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,79 @@
+package propel
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPropelDataPoolsDataSourceBasic(t *testing.T) {
+	ctx := map[string]interface{}{}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckPropelDataPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPropelDataPoolsDataSourceConfigBasic(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.propel_data_pools.foo", "data_pools.#", "1"),
+					resource.TestCheckResourceAttrPair("data.propel_data_pools.foo", "data_pools.0.id", "propel_data_pool.bar", "id"),
+					resource.TestCheckResourceAttrPair("data.propel_data_pools.foo", "data_pools.0.table", "propel_data_pool.bar", "table"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPropelDataPoolsDataSourceConfigBasic(ctx map[string]interface{}) string {
+	return testAccCheckPropelDataPoolConfigBasic(ctx) + `
+
+	data "propel_data_pools" "foo" {
+		name_regex = "^${propel_data_pool.bar.unique_name}$"
+		data_source = propel_data_pool.bar.data_source
+	}`
+}
+
+func TestUnitPropelDataPoolsDataSourcePagination(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+	server.SetMaxPageSize(1)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_data_pool"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitPropelDataPoolsDataSourceConfigPagination(),
+			},
+			{
+				// The Data Pools data source is read once both Data Pools exist, which takes two pages.
+				Config: testUnitPropelDataPoolsDataSourceConfigPagination() + `
+
+				data "propel_data_pools" "foo" {
+					data_source = propel_data_source.foo.id
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.propel_data_pools.foo", "data_pools.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testUnitPropelDataPoolsDataSourceConfigPagination() string {
+	return testAccCheckPropelDataPoolConfigBasic(map[string]interface{}{}) + `
+
+	resource "propel_data_pool" "baz" {
+		unique_name = "terraform-test-4"
+		table = propel_data_source.foo.table[0].name
+		timestamp = "timestamp_tz"
+		data_source = propel_data_source.foo.id
+
+		column {
+			name = "timestamp_tz"
+			type = "TIMESTAMP"
+			nullable = false
+		}
+	}`
+}
@@ -0,0 +1,69 @@
+package propel
+
+import (
+	"context"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/propeldata/terraform-provider-propel/propel/internal/utils"
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+func dataSourceDataSource() *schema.Resource {
+	dsSchema := utils.DatasourceSchemaFromResourceSchema(resourceDataSource().Schema)
+
+	dsSchema["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "unique_name"},
+		Description:  "The Data Source's ID.",
+	}
+	dsSchema["unique_name"].Optional = true
+	dsSchema["unique_name"].ExactlyOneOf = []string{"id", "unique_name"}
+
+	return &schema.Resource{
+		ReadContext: dataSourceDataSourceRead,
+		Description: "Provides a Propel Data Source data source. This can be used to look up an existing Propel Data Source by ID or unique name.",
+		Schema:      dsSchema,
+	}
+}
+
+func dataSourceDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	if id, ok := d.GetOk("id"); ok {
+		d.SetId(id.(string))
+
+		return dataSourceDataSourceReadById(ctx, d, meta)
+	}
+
+	uniqueName := d.Get("unique_name").(string)
+
+	response, err := pc.DataSourceByName(ctx, c, uniqueName)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	if response.DataSource == nil {
+		return diag.Errorf("Data Source %q not found", uniqueName)
+	}
+
+	d.SetId(response.DataSource.Id)
+
+	return dataSourceDataSourceReadById(ctx, d, meta)
+}
+
+// dataSourceDataSourceReadById reads the Data Source with the ID in d, failing if it does not exist.
+func dataSourceDataSourceReadById(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	diags := resourceDataSourceRead(ctx, d, meta)
+	if !diags.HasError() && d.Id() == "" {
+		return diag.Errorf("Data Source %q not found", id)
+	}
+
+	return diags
+}
@@ -0,0 +1,74 @@
+package propel
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestUnitPropelDataSourceDataSourceBasic(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+
+	ctx := map[string]interface{}{
+		"resource_name":       "foo",
+		"unique_name":         acctest.RandString(10),
+		"snowflake_account":   "account",
+		"snowflake_database":  "database",
+		"snowflake_warehouse": "warehouse",
+		"snowflake_schema":    "schema",
+		"snowflake_role":      "role",
+		"snowflake_username":  "username",
+		"snowflake_password":  "password",
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_data_source"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitPropelDataSourceDataSourceConfigBasic(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.propel_data_source.by_id", "unique_name", "propel_data_source.foo", "unique_name"),
+					resource.TestCheckResourceAttrPair("data.propel_data_source.by_id", "type", "propel_data_source.foo", "type"),
+					resource.TestCheckResourceAttrPair("data.propel_data_source.by_id", "snowflake_connection_settings.0.warehouse", "propel_data_source.foo", "snowflake_connection_settings.0.warehouse"),
+					resource.TestCheckResourceAttrPair("data.propel_data_source.by_name", "id", "propel_data_source.foo", "id"),
+					resource.TestCheckResourceAttrPair("data.propel_data_source.by_name", "snowflake_connection_settings.0.account", "propel_data_source.foo", "snowflake_connection_settings.0.account"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceDataSourceSensitive(t *testing.T) {
+	attributes := dataSourceDataSource().CoreConfigSchema().Attributes
+
+	// The connection settings hold passwords, keys and credentials, which must not be shown.
+	for _, name := range []string{
+		"snowflake_connection_settings",
+		"http_connection_settings",
+		"s3_connection_settings",
+		"bigquery_connection_settings",
+		"redshift_connection_settings",
+	} {
+		if attribute, ok := attributes[name]; !ok || !attribute.Sensitive {
+			t.Errorf("expected %s to be a sensitive attribute", name)
+		}
+	}
+
+	if attributes["table"].Sensitive {
+		t.Error("expected table not to be sensitive")
+	}
+}
+
+func testUnitPropelDataSourceDataSourceConfigBasic(ctx map[string]interface{}) string {
+	return testAccCheckPropelDataSourceSnowflakeConfigBroken(ctx) + `
+
+	data "propel_data_source" "by_id" {
+		id = propel_data_source.foo.id
+	}
+
+	data "propel_data_source" "by_name" {
+		unique_name = propel_data_source.foo.unique_name
+	}`
+}
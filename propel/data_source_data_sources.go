@@ -0,0 +1,156 @@
+package propel
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/propeldata/terraform-provider-propel/propel/internal/utils"
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+func dataSourceDataSources() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDataSourcesRead,
+		Description: "Provides a list of Propel Data Sources. This can be used to look up every Data Source in the Environment, optionally filtered by name, type or status.",
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "A regular expression that the Data Sources' unique names must match.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return Data Sources of this type.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return Data Sources with this status.",
+			},
+			"data_sources": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Data Sources that match the filters.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Data Source's ID.",
+						},
+						"unique_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Data Source's name.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Data Source's description.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Data Source's type.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Data Source's status.",
+						},
+						"account": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Account that the Data Source belongs to.",
+						},
+						"environment": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Environment that the Data Source belongs to.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDataSourcesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	var diags diag.Diagnostics
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	dataSourceType := d.Get("type").(string)
+	status := d.Get("status").(string)
+
+	dataSources := make([]map[string]interface{}, 0)
+	ids := make([]string, 0)
+
+	first := pc.PageSize
+	var after *string
+
+	for {
+		response, err := pc.DataSources(ctx, c, &first, nil, after, nil)
+		if err != nil {
+			return diagFromErr(err)
+		}
+
+		if response.DataSources == nil {
+			break
+		}
+
+		for _, edge := range response.DataSources.Edges {
+			dataSource := edge.Node
+
+			if nameRegex != nil && !nameRegex.MatchString(dataSource.UniqueName) {
+				continue
+			}
+
+			if dataSourceType != "" && !strings.EqualFold(string(dataSource.Type), dataSourceType) {
+				continue
+			}
+
+			if status != "" && !strings.EqualFold(string(dataSource.Status), status) {
+				continue
+			}
+
+			dataSources = append(dataSources, map[string]interface{}{
+				"id":          dataSource.Id,
+				"unique_name": dataSource.UniqueName,
+				"description": dataSource.Description,
+				"type":        string(dataSource.Type),
+				"status":      string(dataSource.Status),
+				"account":     dataSource.Account.Id,
+				"environment": dataSource.Environment.Id,
+			})
+			ids = append(ids, dataSource.Id)
+		}
+
+		pageInfo := response.DataSources.PageInfo
+		if pageInfo == nil || !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			break
+		}
+
+		after = pageInfo.EndCursor
+	}
+
+	d.SetId(utils.DatasourceIdFromIds(ids))
+	if err := d.Set("data_sources", dataSources); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,116 @@
+package propel
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestUnitPropelDataSourcesDataSourceFilters(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+	// A page per Data Source makes Read page through the Data Sources.
+	server.SetMaxPageSize(1)
+
+	ctx := map[string]interface{}{
+		"unique_name": acctest.RandString(10),
+	}
+
+	var brokenID string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_data_source"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitPropelDataSourcesDataSourceConfigFilters(ctx),
+				Check:  testUnitStoreID("propel_data_source.broken", &brokenID),
+			},
+			{
+				// The Data Sources data sources are read once the Data Sources exist.
+				PreConfig: func() {
+					server.SetStatus(brokenID, "BROKEN")
+				},
+				Config: testUnitPropelDataSourcesDataSourceConfigFilters(ctx) + Nprintf(`
+
+				data "propel_data_sources" "by_name" {
+					name_regex = "^%{unique_name}-"
+				}
+
+				data "propel_data_sources" "by_type" {
+					name_regex = "^%{unique_name}-"
+					type = "s3"
+				}
+
+				data "propel_data_sources" "by_status" {
+					name_regex = "^%{unique_name}-"
+					status = "BROKEN"
+				}`, ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.propel_data_sources.by_name", "data_sources.#", "3"),
+					resource.TestCheckResourceAttr("data.propel_data_sources.by_type", "data_sources.#", "1"),
+					resource.TestCheckResourceAttrPair("data.propel_data_sources.by_type", "data_sources.0.id", "propel_data_source.s3", "id"),
+					resource.TestCheckResourceAttr("data.propel_data_sources.by_type", "data_sources.0.type", "S3"),
+					resource.TestCheckResourceAttr("data.propel_data_sources.by_status", "data_sources.#", "1"),
+					resource.TestCheckResourceAttrPair("data.propel_data_sources.by_status", "data_sources.0.id", "propel_data_source.broken", "id"),
+					resource.TestCheckResourceAttr("data.propel_data_sources.by_status", "data_sources.0.status", "BROKEN"),
+				),
+			},
+		},
+	})
+}
+
+func testUnitPropelDataSourcesDataSourceConfigFilters(ctx map[string]interface{}) string {
+	return Nprintf(`
+	resource "propel_data_source" "http" {
+		unique_name = "%{unique_name}-http"
+		type = "Http"
+
+		table {
+			name = "events"
+
+			column {
+				name = "timestamp_tz"
+				type = "TIMESTAMP"
+				nullable = false
+			}
+		}
+	}
+
+	resource "propel_data_source" "broken" {
+		unique_name = "%{unique_name}-broken"
+		type = "Http"
+
+		table {
+			name = "events"
+
+			column {
+				name = "timestamp_tz"
+				type = "TIMESTAMP"
+				nullable = false
+			}
+		}
+	}
+
+	resource "propel_data_source" "s3" {
+		unique_name = "%{unique_name}-s3"
+		type = "S3"
+
+		s3_connection_settings {
+			bucket = "bucket"
+			aws_access_key_id = "key"
+			aws_secret_access_key = "secret"
+		}
+
+		table {
+			name = "events"
+			path = "events/*.parquet"
+
+			column {
+				name = "timestamp_tz"
+				type = "TIMESTAMP"
+				nullable = false
+			}
+		}
+	}`, ctx)
+}
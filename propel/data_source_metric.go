@@ -0,0 +1,69 @@
+package propel
+
+import (
+	"context"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/propeldata/terraform-provider-propel/propel/internal/utils"
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+func dataSourceMetric() *schema.Resource {
+	dsSchema := utils.DatasourceSchemaFromResourceSchema(resourceMetric().Schema)
+
+	dsSchema["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "unique_name"},
+		Description:  "The Metric's ID.",
+	}
+	dsSchema["unique_name"].Optional = true
+	dsSchema["unique_name"].ExactlyOneOf = []string{"id", "unique_name"}
+
+	return &schema.Resource{
+		ReadContext: dataSourceMetricRead,
+		Description: "Provides a Propel Metric data source. This can be used to look up an existing Propel Metric by ID or unique name.",
+		Schema:      dsSchema,
+	}
+}
+
+func dataSourceMetricRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	if id, ok := d.GetOk("id"); ok {
+		d.SetId(id.(string))
+
+		return dataSourceMetricReadById(ctx, d, meta)
+	}
+
+	uniqueName := d.Get("unique_name").(string)
+
+	response, err := pc.MetricByName(ctx, c, uniqueName)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	if response.Metric == nil {
+		return diag.Errorf("Metric %q not found", uniqueName)
+	}
+
+	d.SetId(response.Metric.Id)
+
+	return dataSourceMetricReadById(ctx, d, meta)
+}
+
+// dataSourceMetricReadById reads the Metric with the ID in d, failing if it does not exist.
+func dataSourceMetricReadById(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	diags := resourceMetricRead(ctx, d, meta)
+	if !diags.HasError() && d.Id() == "" {
+		return diag.Errorf("Metric %q not found", id)
+	}
+
+	return diags
+}
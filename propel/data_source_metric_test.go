@@ -0,0 +1,47 @@
+package propel
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestUnitPropelMetricDataSourceBasic(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+
+	ctx := map[string]interface{}{
+		"unique_name":  acctest.RandString(10),
+		"filter_value": "foo",
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_metric"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitPropelMetricDataSourceConfigBasic(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.propel_metric.by_id", "unique_name", "propel_metric.baz", "unique_name"),
+					resource.TestCheckResourceAttrPair("data.propel_metric.by_id", "type", "propel_metric.baz", "type"),
+					resource.TestCheckResourceAttrPair("data.propel_metric.by_id", "data_pool", "propel_metric.baz", "data_pool"),
+					resource.TestCheckResourceAttrPair("data.propel_metric.by_name", "id", "propel_metric.baz", "id"),
+					resource.TestCheckResourceAttrPair("data.propel_metric.by_name", "measure", "propel_metric.baz", "measure"),
+					resource.TestCheckResourceAttr("data.propel_metric.by_name", "filter.0.value", "foo"),
+				),
+			},
+		},
+	})
+}
+
+func testUnitPropelMetricDataSourceConfigBasic(ctx map[string]interface{}) string {
+	return testUnitPropelMetricConfigBasic(ctx) + `
+
+	data "propel_metric" "by_id" {
+		id = propel_metric.baz.id
+	}
+
+	data "propel_metric" "by_name" {
+		unique_name = propel_metric.baz.unique_name
+	}`
+}
@@ -0,0 +1,179 @@
+package propel
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/propeldata/terraform-provider-propel/propel/internal/utils"
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+func dataSourceMetrics() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMetricsRead,
+		Description: "Provides a list of Propel Metrics. This can be used to look up every Metric in the Environment, optionally filtered by name, type or Data Pool.",
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "A regular expression that the Metrics' unique names must match.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return Metrics of this type.",
+			},
+			"data_pool": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return Metrics powered by this Data Pool.",
+			},
+			"metrics": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Metrics that match the filters.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Metric's ID.",
+						},
+						"unique_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Metric's name.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Metric's description.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Metric type.",
+						},
+						"data_pool": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Data Pool that powers the Metric.",
+						},
+						"dimensions": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The Metric's Dimensions.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"measure": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Metric's measure, if any.",
+						},
+						"account": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Account that the Metric belongs to.",
+						},
+						"environment": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Environment that the Metric belongs to.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMetricsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	var diags diag.Diagnostics
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	metricType := d.Get("type").(string)
+	dataPoolId := d.Get("data_pool").(string)
+
+	metrics := make([]map[string]interface{}, 0)
+	ids := make([]string, 0)
+
+	first := pc.PageSize
+	var after *string
+
+	for {
+		response, err := pc.Metrics(ctx, c, &first, nil, after, nil)
+		if err != nil {
+			return diagFromErr(err)
+		}
+
+		if response.Metrics == nil {
+			break
+		}
+
+		for _, edge := range response.Metrics.Edges {
+			metric := edge.Node
+
+			if nameRegex != nil && !nameRegex.MatchString(metric.UniqueName) {
+				continue
+			}
+
+			if metricType != "" && !strings.EqualFold(string(metric.Type), metricType) {
+				continue
+			}
+
+			if dataPoolId != "" && metric.DataPool.Id != dataPoolId {
+				continue
+			}
+
+			dimensions := make([]string, 0, len(metric.Dimensions))
+			for _, dimension := range metric.Dimensions {
+				dimensions = append(dimensions, dimension.ColumnName)
+			}
+
+			measure := ""
+			if metric.Measure != nil {
+				measure = metric.Measure.ColumnName
+			}
+
+			metrics = append(metrics, map[string]interface{}{
+				"id":          metric.Id,
+				"unique_name": metric.UniqueName,
+				"description": metric.Description,
+				"type":        string(metric.Type),
+				"data_pool":   metric.DataPool.Id,
+				"dimensions":  dimensions,
+				"measure":     measure,
+				"account":     metric.Account.Id,
+				"environment": metric.Environment.Id,
+			})
+			ids = append(ids, metric.Id)
+		}
+
+		pageInfo := response.Metrics.PageInfo
+		if pageInfo == nil || !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			break
+		}
+
+		after = pageInfo.EndCursor
+	}
+
+	d.SetId(utils.DatasourceIdFromIds(ids))
+	if err := d.Set("metrics", metrics); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,105 @@
+package propel
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestUnitPropelMetricsDataSourceFilters(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+	// A page per Metric makes Read page through the Metrics.
+	server.SetMaxPageSize(1)
+
+	ctx := map[string]interface{}{
+		"unique_name": acctest.RandString(10),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_metric"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitPropelMetricsDataSourceConfigFilters(ctx),
+			},
+			{
+				// The Metrics data sources are read once the Metrics exist.
+				Config: testUnitPropelMetricsDataSourceConfigFilters(ctx) + Nprintf(`
+
+				data "propel_metrics" "by_name" {
+					name_regex = "^%{unique_name}-"
+				}
+
+				data "propel_metrics" "by_type" {
+					name_regex = "^%{unique_name}-"
+					type = "count"
+				}
+
+				data "propel_metrics" "by_data_pool" {
+					name_regex = "^%{unique_name}-"
+					data_pool = propel_data_pool.qux.id
+				}`, ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.propel_metrics.by_name", "metrics.#", "3"),
+					resource.TestCheckResourceAttr("data.propel_metrics.by_type", "metrics.#", "1"),
+					resource.TestCheckResourceAttrPair("data.propel_metrics.by_type", "metrics.0.id", "propel_metric.count", "id"),
+					resource.TestCheckResourceAttr("data.propel_metrics.by_data_pool", "metrics.#", "1"),
+					resource.TestCheckResourceAttrPair("data.propel_metrics.by_data_pool", "metrics.0.id", "propel_metric.other", "id"),
+					resource.TestCheckResourceAttr("data.propel_metrics.by_data_pool", "metrics.0.measure", "value"),
+					resource.TestCheckResourceAttr("data.propel_metrics.by_data_pool", "metrics.0.dimensions.0", "account_id"),
+				),
+			},
+		},
+	})
+}
+
+func testUnitPropelMetricsDataSourceConfigFilters(ctx map[string]interface{}) string {
+	return testUnitPropelMetricConfigDataPool(ctx) + Nprintf(`
+
+	resource "propel_data_pool" "qux" {
+		unique_name = "%{unique_name}-qux"
+		table = propel_data_source.foo.table[0].name
+		timestamp = "timestamp_tz"
+		data_source = propel_data_source.foo.id
+
+		column {
+			name = "timestamp_tz"
+			type = "TIMESTAMP"
+			nullable = false
+		}
+
+		column {
+			name = "account_id"
+			type = "STRING"
+			nullable = false
+		}
+
+		column {
+			name = "value"
+			type = "INT64"
+			nullable = false
+		}
+	}
+
+	resource "propel_metric" "sum" {
+		unique_name = "%{unique_name}-sum"
+		type = "SUM"
+		data_pool = propel_data_pool.bar.id
+		measure = "value"
+	}
+
+	resource "propel_metric" "count" {
+		unique_name = "%{unique_name}-count"
+		type = "COUNT"
+		data_pool = propel_data_pool.bar.id
+	}
+
+	resource "propel_metric" "other" {
+		unique_name = "%{unique_name}-other"
+		type = "SUM"
+		data_pool = propel_data_pool.qux.id
+		measure = "value"
+		dimensions = ["account_id"]
+	}`, ctx)
+}
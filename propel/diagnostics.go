@@ -0,0 +1,70 @@
+package propel
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+// inputFieldAttributes maps the top-level GraphQL input fields that are named the same across resources to their
+// attributes. Other fields are not mapped, since pointing at the wrong attribute is worse than pointing at none.
+var inputFieldAttributes = map[string]string{
+	"uniqueName":  "unique_name",
+	"description": "description",
+}
+
+// diagFromErr converts an error into diagnostics like diag.FromErr, keeping the error's message as the summary.
+// Propel API errors leave their error code out of the summary and report it in the detail instead, and point at the
+// attribute for the input field the API rejected when it is known.
+func diagFromErr(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *pc.Error
+	if !errors.As(err, &apiErr) {
+		return diag.FromErr(err)
+	}
+
+	summary := strings.Replace(err.Error(), apiErr.Error(), apiErr.Message, 1)
+
+	return diag.Diagnostics{diagFromAPIError(summary, apiErr)}
+}
+
+// diagFromFailure converts the Error in a FailureResponse into diagnostics whose summary is the given one followed
+// by the error's message.
+func diagFromFailure(summary string, code *int, message string) diag.Diagnostics {
+	return diag.Diagnostics{diagFromAPIError(summary+": "+message, pc.NewFailureError(code, message))}
+}
+
+func diagFromAPIError(summary string, apiErr *pc.Error) diag.Diagnostic {
+	diagnostic := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  summary,
+	}
+
+	if apiErr.Code != "" {
+		diagnostic.Detail = "Propel API error code: " + apiErr.Code
+	}
+
+	if path, ok := attributePathFromField(apiErr.Field); ok {
+		diagnostic.AttributePath = path
+	}
+
+	return diagnostic
+}
+
+// attributePathFromField converts a top-level GraphQL input field, such as input.uniqueName, into the matching
+// attribute path, such as unique_name. It reports false for any other field.
+func attributePathFromField(field string) (cty.Path, bool) {
+	attribute, ok := inputFieldAttributes[strings.TrimPrefix(field, "input.")]
+	if !ok {
+		return nil, false
+	}
+
+	return cty.GetAttrPath(attribute), true
+}
@@ -0,0 +1,61 @@
+package propel
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+func TestDiagFromFailure(t *testing.T) {
+	code := 400
+
+	diags := diagFromFailure("Failed to create Data Source", &code, "Invalid Snowflake account")
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+
+	if diags[0].Summary != "Failed to create Data Source: Invalid Snowflake account" {
+		t.Fatalf("unexpected summary %q", diags[0].Summary)
+	}
+
+	if diags[0].Detail != "Propel API error code: 400" {
+		t.Fatalf("unexpected detail %q", diags[0].Detail)
+	}
+}
+
+func TestDiagFromErr(t *testing.T) {
+	apiErr := &pc.Error{Kind: pc.ErrorKindValidation, Code: "BAD_USER_INPUT", Message: "Invalid name"}
+
+	diags := diagFromErr(fmt.Errorf("error reading Data Pool DPO1: %w", apiErr))
+
+	if diags[0].Summary != "error reading Data Pool DPO1: Invalid name" {
+		t.Fatalf("unexpected summary %q", diags[0].Summary)
+	}
+
+	if diags[0].Detail != "Propel API error code: BAD_USER_INPUT" {
+		t.Fatalf("unexpected detail %q", diags[0].Detail)
+	}
+
+	if diags := diagFromErr(errors.New("boom")); diags[0].Summary != "boom" {
+		t.Fatalf("unexpected summary %q", diags[0].Summary)
+	}
+}
+
+func TestDiagFromErrAttributePath(t *testing.T) {
+	diags := diagFromErr(&pc.Error{Kind: pc.ErrorKindValidation, Message: "Invalid name", Field: "input.uniqueName"})
+
+	if !diags[0].AttributePath.Equals(cty.GetAttrPath("unique_name")) {
+		t.Fatalf("unexpected attribute path %#v", diags[0].AttributePath)
+	}
+
+	// Nested fields could belong to several attributes, so they are not mapped.
+	diags = diagFromErr(&pc.Error{Kind: pc.ErrorKindValidation, Message: "Invalid account", Field: "input.connectionSettings.account"})
+
+	if diags[0].AttributePath != nil {
+		t.Fatalf("unexpected attribute path %#v", diags[0].AttributePath)
+	}
+}
@@ -0,0 +1,63 @@
+package propel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+// importNamePrefix marks the ID given to `terraform import` as a unique name, as in "name:My Data Pool".
+const importNamePrefix = "name:"
+
+// parseImportId returns the ID or unique name given to `terraform import`, and whether it was explicitly given as a
+// unique name.
+func parseImportId(id string) (string, bool) {
+	if strings.HasPrefix(id, importNamePrefix) {
+		return strings.TrimPrefix(id, importNamePrefix), true
+	}
+
+	return id, false
+}
+
+// importLookup looks up an object by ID or by unique name, returning its ID, or an empty string if there is none.
+type importLookup func(ctx context.Context, c graphql.Client, idOrUniqueName string) (string, error)
+
+// importByIdOrUniqueName imports the object of the given kind, such as "Data Pool", whose ID or unique name was given
+// to `terraform import`. The object is looked up by ID first, unless a unique name was explicitly given, and by unique
+// name when there is no object with that ID.
+func importByIdOrUniqueName(ctx context.Context, d *schema.ResourceData, meta interface{}, kind string, byId, byName importLookup) ([]*schema.ResourceData, error) {
+	c := meta.(graphql.Client)
+
+	idOrUniqueName, explicitName := parseImportId(d.Id())
+
+	if !explicitName {
+		id, err := byId(ctx, c, idOrUniqueName)
+		if err != nil && !pc.IsNotFound(err) {
+			return nil, err
+		}
+
+		if err == nil && id != "" {
+			d.SetId(id)
+
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	id, err := byName(ctx, c, idOrUniqueName)
+	if err != nil && !pc.IsNotFound(err) {
+		return nil, err
+	}
+
+	if err != nil || id == "" {
+		return nil, fmt.Errorf("no %s found with ID or unique name \"%s\"", kind, idOrUniqueName)
+	}
+
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,37 @@
+// Command fakepropel serves the fake Propel API until it is interrupted, so that acceptance tests can record their
+// cassettes without a Propel account. It prints the environment variables that point the provider at it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/propeldata/terraform-provider-propel/propel/internal/fakepropel"
+)
+
+func main() {
+	broken := flag.String("broken", "", "comma-separated types of the Data Sources that end up BROKEN, as they do when Propel cannot connect to them")
+	flag.Parse()
+
+	server := fakepropel.NewServer()
+	defer server.Close()
+
+	for _, dataSourceType := range strings.Split(*broken, ",") {
+		if dataSourceType != "" {
+			server.SetDataSourceTransitions(dataSourceType, "CONNECTING", "BROKEN")
+		}
+	}
+
+	fmt.Printf("PROPEL_CLIENT_ID=%s\n", server.ClientID)
+	fmt.Printf("PROPEL_CLIENT_SECRET=%s\n", server.ClientSecret)
+	fmt.Printf("PROPEL_API_URL=%s\n", server.GraphQLURL())
+	fmt.Printf("PROPEL_OAUTH_URL=%s\n", server.TokenURL())
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	<-interrupt
+}
@@ -0,0 +1,1195 @@
+package fakepropel
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+// operation handles a GraphQL operation, returning the operation's data.
+type operation func(s *Server, variables json.RawMessage) (map[string]interface{}, error)
+
+// operations are the GraphQL operations served by the fake, keyed by operation name. They cover the operations
+// generated from genqlient.yaml.
+var operations map[string]operation
+
+func init() {
+	operations = map[string]operation{
+		"CreateApplication":         createApplication,
+		"CreateAverageMetric":       createMetric("createAverageMetric", "AVERAGE"),
+		"CreateBigQueryDataSource":  createBigQueryDataSource,
+		"CreateBooster":             createBooster,
+		"CreateCountDistinctMetric": createMetric("createCountDistinctMetric", "COUNT_DISTINCT"),
+		"CreateCountMetric":         createMetric("createCountMetric", "COUNT"),
+		"CreateDataPool":            createDataPool,
+		"CreateHttpDataSource":      createHttpDataSource,
+		"CreateMaxMetric":           createMetric("createMaxMetric", "MAX"),
+		"CreateMinMetric":           createMetric("createMinMetric", "MIN"),
+		"CreatePolicy":              createPolicy,
+		"CreateRedshiftDataSource":  createRedshiftDataSource,
+		"CreateS3DataSource":        createS3DataSource,
+		"CreateSnowflakeDataSource": createSnowflakeDataSource,
+		"CreateSumMetric":           createMetric("createSumMetric", "SUM"),
+		"DeleteApplication":         deleteByID("deleteApplication", TypeApplication),
+		"DeleteApplicationByName":   deleteByName("deleteApplicationByName", TypeApplication),
+		"DeleteBooster":             deleteByID("deleteBooster", TypeBooster),
+		"DeleteDataPool":            deleteByID("deleteDataPool", TypeDataPool),
+		"DeleteDataPoolByName":      deleteByName("deleteDataPoolByName", TypeDataPool),
+		"DeleteDataSource":          deleteByID("deleteDataSource", TypeDataSource),
+		"DeleteDataSourceByName":    deleteByName("deleteDataSourceByName", TypeDataSource),
+		"DeleteMetric":              deleteByID("deleteMetric", TypeMetric),
+		"DeleteMetricByName":        deleteByName("deleteMetricByName", TypeMetric),
+		"DeletePolicy":              deleteByID("deletePolicy", TypePolicy),
+		"MigrateMetric":             migrateMetric,
+		"ModifyApplication":         modifyApplication,
+		"ModifyBigQueryDataSource":  modifyBigQueryDataSource,
+		"ModifyDataPool":            modifyDataPool,
+		"ModifyHttpDataSource":      modifyHttpDataSource,
+		"ModifyMetric":              modifyMetric,
+		"ModifyPolicy":              modifyPolicy,
+		"ModifyRedshiftDataSource":  modifyRedshiftDataSource,
+		"ModifyS3DataSource":        modifyS3DataSource,
+		"ModifySnowflakeDataSource": modifySnowflakeDataSource,
+		"ReconnectDataSource":       reconnectDataSource,
+		"Application":               getByID("application", TypeApplication),
+		"ApplicationByClientId":     applicationByClientID,
+		"ApplicationByName":         getByName("application", TypeApplication),
+		"Applications":              list("applications", TypeApplication),
+		"Booster":                   getByID("booster", TypeBooster),
+		"DataPool":                  getByID("dataPool", TypeDataPool),
+		"DataPoolByName":            getByName("dataPool", TypeDataPool),
+		"DataPoolColumns":           dataPoolColumns,
+		"DataPools":                 list("dataPools", TypeDataPool),
+		"DataSource":                getByID("dataSource", TypeDataSource),
+		"DataSourceByName":          getByName("dataSource", TypeDataSource),
+		"DataSourceTables":          dataSourceTables,
+		"DataSources":               list("dataSources", TypeDataSource),
+		"Metric":                    getByID("metric", TypeMetric),
+		"MetricByName":              getByName("metric", TypeMetric),
+		"Metrics":                   list("metrics", TypeMetric),
+		"Policy":                    getByID("policy", TypePolicy),
+		"TableColumns":              tableColumns,
+	}
+}
+
+func decode(variables json.RawMessage, v interface{}) error {
+	if err := json.Unmarshal(variables, v); err != nil {
+		return newError("BAD_USER_INPUT", fmt.Sprintf("invalid variables: %s", err))
+	}
+
+	return nil
+}
+
+// response wraps an object in the response union returned by create and modify mutations, such as DataSourceResponse.
+func response(field string, s *Server, o *object) map[string]interface{} {
+	key := strings.ToLower(o.typename[:1]) + o.typename[1:]
+
+	return map[string]interface{}{
+		field: map[string]interface{}{
+			"__typename": o.typename + "Response",
+			key:          s.render(o),
+		},
+	}
+}
+
+func getByID(field, typename string) operation {
+	return func(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+		var v struct {
+			Id string `json:"id"`
+		}
+		if err := decode(variables, &v); err != nil {
+			return nil, err
+		}
+
+		o, err := s.find(typename, v.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{field: s.read(o)}, nil
+	}
+}
+
+func getByName(field, typename string) operation {
+	return func(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+		var v struct {
+			UniqueName string `json:"uniqueName"`
+		}
+		if err := decode(variables, &v); err != nil {
+			return nil, err
+		}
+
+		o, err := s.findByName(typename, v.UniqueName)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{field: s.read(o)}, nil
+	}
+}
+
+func list(field, typename string) operation {
+	return func(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+		var v struct {
+			First *int    `json:"first"`
+			After *string `json:"after"`
+		}
+		if err := decode(variables, &v); err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{field: s.list(typename, v.First, v.After)}, nil
+	}
+}
+
+func deleteByID(field, typename string) operation {
+	return func(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+		var v struct {
+			Id string `json:"id"`
+		}
+		if err := decode(variables, &v); err != nil {
+			return nil, err
+		}
+
+		if err := s.delete(typename, v.Id); err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{field: v.Id}, nil
+	}
+}
+
+func deleteByName(field, typename string) operation {
+	return func(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+		var v struct {
+			UniqueName string `json:"uniqueName"`
+		}
+		if err := decode(variables, &v); err != nil {
+			return nil, err
+		}
+
+		o, err := s.findByName(typename, v.UniqueName)
+		if err != nil {
+			return nil, err
+		}
+
+		delete(s.objects, o.id)
+
+		return map[string]interface{}{field: o.id}, nil
+	}
+}
+
+// Data Sources
+
+func newDataSource(dataSourceType string, connectionSettings map[string]interface{}, tables []interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type":               dataSourceType,
+		"status":             "CREATED",
+		"error":              nil,
+		"connectionSettings": connectionSettings,
+		"tables":             map[string]interface{}{"nodes": tables},
+		"checks":             []interface{}{},
+		"tableIntrospections": map[string]interface{}{
+			"nodes": []interface{}{},
+		},
+	}
+}
+
+func (s *Server) newTable(name string, columns []interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"id":      s.newID(tableIDPrefix),
+		"name":    name,
+		"columns": map[string]interface{}{"nodes": columns},
+	}
+}
+
+func newColumn(name string, columnType pc.ColumnType, nullable bool) map[string]interface{} {
+	return map[string]interface{}{
+		"name":       name,
+		"type":       columnType,
+		"isNullable": nullable,
+	}
+}
+
+func createSnowflakeDataSource(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.CreateSnowflakeDataSourceInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	settings := map[string]interface{}{"__typename": "SnowflakeConnectionSettings"}
+	if cs := v.Input.ConnectionSettings; cs != nil {
+		settings["account"] = cs.Account
+		settings["database"] = cs.Database
+		settings["warehouse"] = cs.Warehouse
+		settings["schema"] = cs.Schema
+		settings["username"] = cs.Username
+		settings["role"] = cs.Role
+	}
+
+	o, err := s.createNamed(TypeDataSource, v.Input.UniqueName, v.Input.Description, newDataSource("Snowflake", settings, []interface{}{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return response("createSnowflakeDataSource", s, o), nil
+}
+
+func createHttpDataSource(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.CreateHttpDataSourceInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	settings := map[string]interface{}{
+		"__typename": "HttpConnectionSettings",
+		"basicAuth":  nil,
+	}
+	tables := []interface{}{}
+
+	if cs := v.Input.ConnectionSettings; cs != nil {
+		if cs.BasicAuth != nil {
+			settings["basicAuth"] = newBasicAuth(cs.BasicAuth)
+		}
+
+		tables = s.newHttpTables(cs.Tables)
+	}
+
+	o, err := s.createNamed(TypeDataSource, v.Input.UniqueName, v.Input.Description, newDataSource("Http", settings, tables))
+	if err != nil {
+		return nil, err
+	}
+
+	return response("createHttpDataSource", s, o), nil
+}
+
+func newBasicAuth(basicAuth *pc.HttpBasicAuthInput) map[string]interface{} {
+	return map[string]interface{}{
+		"username": basicAuth.Username,
+		"password": basicAuth.Password,
+	}
+}
+
+func (s *Server) newHttpTables(input []*pc.HttpDataSourceTableInput) []interface{} {
+	tables := []interface{}{}
+
+	for _, table := range input {
+		columns := []interface{}{}
+		for _, column := range table.Columns {
+			columns = append(columns, newColumn(column.Name, column.Type, column.Nullable))
+		}
+
+		tables = append(tables, s.newTable(table.Name, columns))
+	}
+
+	return tables
+}
+
+func createS3DataSource(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.CreateS3DataSourceInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	settings := map[string]interface{}{
+		"__typename": "S3ConnectionSettings",
+		"tables":     []interface{}{},
+	}
+	tables := []interface{}{}
+
+	if cs := v.Input.ConnectionSettings; cs != nil {
+		settings["bucket"] = cs.Bucket
+		settings["awsAccessKeyId"] = cs.AwsAccessKeyId
+		tables, settings["tables"] = s.newS3Tables(cs.Tables)
+	}
+
+	o, err := s.createNamed(TypeDataSource, v.Input.UniqueName, v.Input.Description, newDataSource("S3", settings, tables))
+	if err != nil {
+		return nil, err
+	}
+
+	return response("createS3DataSource", s, o), nil
+}
+
+// newS3Tables returns an S3 Data Source's tables, along with the tables and paths in its connection settings.
+func (s *Server) newS3Tables(input []*pc.S3DataSourceTableInput) ([]interface{}, []interface{}) {
+	tables := []interface{}{}
+	paths := []interface{}{}
+
+	for _, table := range input {
+		columns := []interface{}{}
+		for _, column := range table.Columns {
+			columns = append(columns, newColumn(column.Name, column.Type, column.Nullable))
+		}
+
+		tables = append(tables, s.newTable(table.Name, columns))
+		paths = append(paths, map[string]interface{}{
+			"name": table.Name,
+			"path": table.Path,
+		})
+	}
+
+	return tables, paths
+}
+
+func createBigQueryDataSource(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.CreateBigQueryDataSourceInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	settings := map[string]interface{}{"__typename": "BigQueryConnectionSettings"}
+	if cs := v.Input.ConnectionSettings; cs != nil {
+		settings["dataSetId"] = cs.DataSetId
+		settings["projectId"] = cs.ProjectId
+	}
+
+	o, err := s.createNamed(TypeDataSource, v.Input.UniqueName, v.Input.Description, newDataSource("BIGQUERY", settings, []interface{}{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return response("createBigQueryDataSource", s, o), nil
+}
+
+func createRedshiftDataSource(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.CreateRedshiftDataSourceInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	settings := map[string]interface{}{"__typename": "RedshiftConnectionSettings"}
+	if cs := v.Input.ConnectionSettings; cs != nil {
+		settings["host"] = cs.Host
+		settings["port"] = cs.Port
+		settings["database"] = cs.Database
+		settings["schema"] = cs.Schema
+		settings["username"] = cs.Username
+		settings["awsAccountId"] = cs.AwsAccountId
+	}
+
+	o, err := s.createNamed(TypeDataSource, v.Input.UniqueName, v.Input.Description, newDataSource("Redshift", settings, []interface{}{}))
+	if err != nil {
+		return nil, err
+	}
+
+	// The API generates the role it assumes to access the cluster.
+	settings["propelRoleArn"] = fmt.Sprintf("arn:aws:iam::000000000000:role/propel-%s", o.id)
+
+	return response("createRedshiftDataSource", s, o), nil
+}
+
+// modifyDataSource applies the changes common to the modify Data Source mutations and merges the given connection
+// settings, skipping the ones that are not set.
+func (s *Server) modifyDataSource(idOrUniqueName *pc.IdOrUniqueName, uniqueName, description *string, settings map[string]interface{}) (*object, error) {
+	if idOrUniqueName == nil {
+		return nil, newError("BAD_USER_INPUT", "idOrUniqueName must be set")
+	}
+
+	o, err := s.findByIdOrUniqueName(TypeDataSource, idOrUniqueName.Id, idOrUniqueName.UniqueName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.modify(o, uniqueName, description); err != nil {
+		return nil, err
+	}
+
+	current := o.fields["connectionSettings"].(map[string]interface{})
+	for key, value := range settings {
+		if value != nil {
+			current[key] = value
+		}
+	}
+
+	return o, nil
+}
+
+func modifySnowflakeDataSource(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.ModifySnowflakeDataSourceInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	settings := map[string]interface{}{}
+	if cs := v.Input.ConnectionSettings; cs != nil {
+		settings = map[string]interface{}{
+			"account":   cs.Account,
+			"database":  cs.Database,
+			"warehouse": cs.Warehouse,
+			"schema":    cs.Schema,
+			"username":  cs.Username,
+			"role":      cs.Role,
+		}
+	}
+
+	o, err := s.modifyDataSource(v.Input.IdOrUniqueName, v.Input.UniqueName, v.Input.Description, nonNil(settings))
+	if err != nil {
+		return nil, err
+	}
+
+	return response("modifySnowflakeDataSource", s, o), nil
+}
+
+func modifyHttpDataSource(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.ModifyHttpDataSourceInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	o, err := s.modifyDataSource(v.Input.IdOrUniqueName, v.Input.UniqueName, v.Input.Description, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if cs := v.Input.ConnectionSettings; cs != nil {
+		settings := o.fields["connectionSettings"].(map[string]interface{})
+
+		if cs.BasicAuthEnabled != nil && !*cs.BasicAuthEnabled {
+			settings["basicAuth"] = nil
+		} else if cs.BasicAuth != nil {
+			settings["basicAuth"] = newBasicAuth(cs.BasicAuth)
+		}
+
+		if cs.Tables != nil {
+			o.fields["tables"] = map[string]interface{}{"nodes": s.newHttpTables(cs.Tables)}
+		}
+	}
+
+	return response("modifyHttpDataSource", s, o), nil
+}
+
+func modifyBigQueryDataSource(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.ModifyBigQueryDataSourceInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	settings := map[string]interface{}{}
+	if cs := v.Input.ConnectionSettings; cs != nil {
+		settings = map[string]interface{}{
+			"dataSetId": cs.DataSetId,
+			"projectId": cs.ProjectId,
+		}
+	}
+
+	o, err := s.modifyDataSource(v.Input.IdOrUniqueName, v.Input.UniqueName, v.Input.Description, nonNil(settings))
+	if err != nil {
+		return nil, err
+	}
+
+	return response("modifyBigQueryDataSource", s, o), nil
+}
+
+func modifyRedshiftDataSource(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.ModifyRedshiftDataSourceInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	settings := map[string]interface{}{}
+	if cs := v.Input.ConnectionSettings; cs != nil {
+		settings = map[string]interface{}{
+			"host":         cs.Host,
+			"port":         cs.Port,
+			"database":     cs.Database,
+			"schema":       cs.Schema,
+			"username":     cs.Username,
+			"awsAccountId": cs.AwsAccountId,
+		}
+	}
+
+	o, err := s.modifyDataSource(v.Input.IdOrUniqueName, v.Input.UniqueName, v.Input.Description, nonNil(settings))
+	if err != nil {
+		return nil, err
+	}
+
+	return response("modifyRedshiftDataSource", s, o), nil
+}
+
+func modifyS3DataSource(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.ModifyS3DataSourceInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	settings := map[string]interface{}{}
+	if cs := v.Input.ConnectionSettings; cs != nil {
+		settings = map[string]interface{}{
+			"bucket":         cs.Bucket,
+			"awsAccessKeyId": cs.AwsAccessKeyId,
+		}
+	}
+
+	o, err := s.modifyDataSource(v.Input.IdOrUniqueName, v.Input.UniqueName, v.Input.Description, nonNil(settings))
+	if err != nil {
+		return nil, err
+	}
+
+	if cs := v.Input.ConnectionSettings; cs != nil && cs.Tables != nil {
+		tables, paths := s.newS3Tables(cs.Tables)
+		o.fields["tables"] = map[string]interface{}{"nodes": tables}
+		o.fields["connectionSettings"].(map[string]interface{})["tables"] = paths
+	}
+
+	return response("modifyS3DataSource", s, o), nil
+}
+
+// reconnectDataSource sends the Data Source through its transitions again, as Propel does when it reconnects with
+// the Data Source's current connection settings.
+func reconnectDataSource(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.IdOrUniqueName `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	o, err := s.findByIdOrUniqueName(TypeDataSource, v.Input.Id, v.Input.UniqueName)
+	if err != nil {
+		return nil, err
+	}
+
+	o.statuses = s.transitionsOf(o)
+
+	return map[string]interface{}{"reconnectDataSource": s.render(o)}, nil
+}
+
+// nonNil dereferences the pointers in a map of optional input fields, dropping the ones that are nil.
+func nonNil(fields map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{}, len(fields))
+
+	for key, value := range fields {
+		switch v := value.(type) {
+		case *string:
+			if v != nil {
+				values[key] = *v
+			}
+		case *int:
+			if v != nil {
+				values[key] = *v
+			}
+		default:
+			values[key] = v
+		}
+	}
+
+	return values
+}
+
+func dataSourceTables(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Id    string  `json:"id"`
+		First *int    `json:"first"`
+		After *string `json:"after"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	o, err := s.find(TypeDataSource, v.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"dataSource": map[string]interface{}{"tables": s.tables(o, v.First, v.After)},
+	}, nil
+}
+
+// tables returns a page of a Data Source's tables, each with the first page of its columns.
+func (s *Server) tables(o *object, first *int, after *string) map[string]interface{} {
+	tables := []interface{}{}
+	for _, table := range o.fields["tables"].(map[string]interface{})["nodes"].([]interface{}) {
+		table := table.(map[string]interface{})
+		tables = append(tables, map[string]interface{}{
+			"id":      table["id"],
+			"name":    table["name"],
+			"columns": s.connection(table["columns"].(map[string]interface{})["nodes"].([]interface{}), first, nil),
+		})
+	}
+
+	return s.connection(tables, first, after)
+}
+
+func tableColumns(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Id    string  `json:"id"`
+		First *int    `json:"first"`
+		After *string `json:"after"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	for _, o := range s.objects {
+		if o.typename != TypeDataSource {
+			continue
+		}
+
+		for _, table := range o.fields["tables"].(map[string]interface{})["nodes"].([]interface{}) {
+			if table := table.(map[string]interface{}); table["id"] == v.Id {
+				columns := table["columns"].(map[string]interface{})["nodes"].([]interface{})
+
+				return map[string]interface{}{
+					"table": map[string]interface{}{"columns": s.connection(columns, v.First, v.After)},
+				}, nil
+			}
+		}
+	}
+
+	return nil, notFound("Table", v.Id)
+}
+
+// Data Pools
+
+func createDataPool(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.CreateDataPoolInputV2 `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	dataSource, err := s.find(TypeDataSource, v.Input.DataSource)
+	if err != nil {
+		return nil, err
+	}
+
+	table := findTable(dataSource, v.Input.Table)
+	if table == nil {
+		return nil, newError("BAD_USER_INPUT", fmt.Sprintf("table %q not found in Data Source %q", v.Input.Table, dataSource.id))
+	}
+
+	// Without explicit columns, the Data Pool gets the columns of the Data Source's table.
+	columns := []interface{}{}
+	if len(v.Input.Columns) > 0 {
+		for _, column := range v.Input.Columns {
+			columns = append(columns, newDataPoolColumn(column.ColumnName, column.Type, column.IsNullable))
+		}
+	} else {
+		for _, column := range table["columns"].(map[string]interface{})["nodes"].([]interface{}) {
+			column := column.(map[string]interface{})
+			columns = append(columns, newDataPoolColumn(column["name"].(string), column["type"].(pc.ColumnType), column["isNullable"].(bool)))
+		}
+	}
+
+	var timestamp map[string]interface{}
+	if v.Input.Timestamp != nil {
+		column := findColumn(columns, v.Input.Timestamp.ColumnName)
+		if column == nil {
+			return nil, newError("BAD_USER_INPUT", fmt.Sprintf("timestamp column %q not found", v.Input.Timestamp.ColumnName))
+		}
+
+		timestamp = map[string]interface{}{
+			"columnName": column["columnName"],
+			"type":       column["type"],
+		}
+	}
+
+	var tenant map[string]interface{}
+	if v.Input.Tenant != nil {
+		tenant = map[string]interface{}{"columnName": v.Input.Tenant.ColumnName}
+	}
+
+	measures := []interface{}{}
+	for _, column := range columns {
+		switch column.(map[string]interface{})["type"] {
+		case pc.ColumnTypeInt8, pc.ColumnTypeInt16, pc.ColumnTypeInt32, pc.ColumnTypeInt64, pc.ColumnTypeFloat, pc.ColumnTypeDouble:
+			measures = append(measures, column)
+		}
+	}
+
+	o, err := s.createNamed(TypeDataPool, v.Input.UniqueName, v.Input.Description, map[string]interface{}{
+		"status":            "CREATED",
+		"error":             nil,
+		"table":             v.Input.Table,
+		"timestamp":         timestamp,
+		"tenant":            tenant,
+		"columns":           map[string]interface{}{"nodes": columns},
+		"availableMeasures": map[string]interface{}{"nodes": measures},
+		"setupTasks":        []interface{}{},
+		"syncs":             map[string]interface{}{"nodes": []interface{}{}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	o.parent = dataSource.id
+
+	return map[string]interface{}{
+		"createDataPoolV2": map[string]interface{}{
+			"__typename": "DataPoolResponse",
+			"dataPool":   s.render(o),
+		},
+	}, nil
+}
+
+func newDataPoolColumn(name string, columnType pc.ColumnType, nullable bool) map[string]interface{} {
+	return map[string]interface{}{
+		"columnName": name,
+		"type":       columnType,
+		"isNullable": nullable,
+	}
+}
+
+func findTable(dataSource *object, name string) map[string]interface{} {
+	for _, table := range dataSource.fields["tables"].(map[string]interface{})["nodes"].([]interface{}) {
+		if table := table.(map[string]interface{}); table["name"] == name {
+			return table
+		}
+	}
+
+	return nil
+}
+
+func findColumn(columns []interface{}, name string) map[string]interface{} {
+	for _, column := range columns {
+		if column := column.(map[string]interface{}); column["columnName"] == name {
+			return column
+		}
+	}
+
+	return nil
+}
+
+func dataPoolColumns(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Id    string  `json:"id"`
+		First *int    `json:"first"`
+		After *string `json:"after"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	o, err := s.find(TypeDataPool, v.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := o.fields["columns"].(map[string]interface{})["nodes"].([]interface{})
+
+	return map[string]interface{}{
+		"dataPool": map[string]interface{}{"columns": s.connection(columns, v.First, v.After)},
+	}, nil
+}
+
+func modifyDataPool(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.ModifyDataPoolInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	if v.Input.IdOrUniqueName == nil {
+		return nil, newError("BAD_USER_INPUT", "idOrUniqueName must be set")
+	}
+
+	o, err := s.findByIdOrUniqueName(TypeDataPool, v.Input.IdOrUniqueName.Id, v.Input.IdOrUniqueName.UniqueName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.modify(o, v.Input.UniqueName, v.Input.Description); err != nil {
+		return nil, err
+	}
+
+	return response("modifyDataPool", s, o), nil
+}
+
+// Metrics
+
+// metricInput has the fields of all the create Metric inputs.
+type metricInput struct {
+	DataPool    string               `json:"dataPool"`
+	UniqueName  *string              `json:"uniqueName"`
+	Description *string              `json:"description"`
+	Filters     []*pc.FilterInput    `json:"filters"`
+	Dimensions  []*pc.DimensionInput `json:"dimensions"`
+	Measure     *pc.DimensionInput   `json:"measure"`
+	Dimension   *pc.DimensionInput   `json:"dimension"`
+}
+
+var metricSettingsTypenames = map[string]string{
+	"COUNT":          "CountMetricSettings",
+	"SUM":            "SumMetricSettings",
+	"COUNT_DISTINCT": "CountDistinctMetricSettings",
+	"AVERAGE":        "AverageMetricSettings",
+	"MIN":            "MinMetricSettings",
+	"MAX":            "MaxMetricSettings",
+}
+
+func createMetric(field, metricType string) operation {
+	return func(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+		var v struct {
+			Input metricInput `json:"input"`
+		}
+		if err := decode(variables, &v); err != nil {
+			return nil, err
+		}
+
+		dataPool, err := s.find(TypeDataPool, v.Input.DataPool)
+		if err != nil {
+			return nil, err
+		}
+
+		dimensions, err := newDimensions(dataPool, v.Input.Dimensions)
+		if err != nil {
+			return nil, err
+		}
+
+		var timestamp map[string]interface{}
+		if ts, ok := dataPool.fields["timestamp"].(map[string]interface{}); ok {
+			timestamp = map[string]interface{}{
+				"columnName":  ts["columnName"],
+				"type":        ts["type"],
+				"isNullable":  false,
+				"isUniqueKey": false,
+			}
+		}
+
+		settings := map[string]interface{}{
+			"__typename": metricSettingsTypenames[metricType],
+			"filters":    newFilters(v.Input.Filters),
+		}
+
+		var measure map[string]interface{}
+		switch metricType {
+		case "SUM", "AVERAGE", "MIN", "MAX":
+			if v.Input.Measure == nil {
+				return nil, newError("BAD_USER_INPUT", "measure must be set")
+			}
+
+			if measure, err = newDimension(dataPool, v.Input.Measure.ColumnName); err != nil {
+				return nil, err
+			}
+
+			settings["measure"] = measure
+		case "COUNT_DISTINCT":
+			if v.Input.Dimension == nil {
+				return nil, newError("BAD_USER_INPUT", "dimension must be set")
+			}
+
+			dimension, err := newDimension(dataPool, v.Input.Dimension.ColumnName)
+			if err != nil {
+				return nil, err
+			}
+
+			settings["dimension"] = dimension
+		}
+
+		o, err := s.createNamed(TypeMetric, v.Input.UniqueName, v.Input.Description, map[string]interface{}{
+			"type":       metricType,
+			"dimensions": dimensions,
+			"timestamp":  timestamp,
+			"measure":    measure,
+			"settings":   settings,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		o.parent = dataPool.id
+
+		return response(field, s, o), nil
+	}
+}
+
+func newDimension(dataPool *object, columnName string) (map[string]interface{}, error) {
+	columns := dataPool.fields["columns"].(map[string]interface{})["nodes"].([]interface{})
+
+	column := findColumn(columns, columnName)
+	if column == nil {
+		return nil, newError("BAD_USER_INPUT", fmt.Sprintf("column %q not found in Data Pool %q", columnName, dataPool.id))
+	}
+
+	return map[string]interface{}{
+		"columnName":  columnName,
+		"type":        column["type"],
+		"isNullable":  column["isNullable"],
+		"isUniqueKey": false,
+	}, nil
+}
+
+func newDimensions(dataPool *object, inputs []*pc.DimensionInput) ([]interface{}, error) {
+	dimensions := []interface{}{}
+
+	for _, input := range inputs {
+		dimension, err := newDimension(dataPool, input.ColumnName)
+		if err != nil {
+			return nil, err
+		}
+
+		dimensions = append(dimensions, dimension)
+	}
+
+	return dimensions, nil
+}
+
+func newFilters(inputs []*pc.FilterInput) []interface{} {
+	filters := []interface{}{}
+
+	for _, input := range inputs {
+		filters = append(filters, map[string]interface{}{
+			"column":   input.Column,
+			"operator": input.Operator,
+			"value":    input.Value,
+		})
+	}
+
+	return filters
+}
+
+func modifyMetric(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.ModifyMetricInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	o, err := s.find(TypeMetric, v.Input.Metric)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.modify(o, v.Input.UniqueName, v.Input.Description); err != nil {
+		return nil, err
+	}
+
+	// The mutation always sends the Dimensions and Filters, so, like the API, null replaces them with none.
+	dataPool, err := s.find(TypeDataPool, o.parent)
+	if err != nil {
+		return nil, err
+	}
+
+	dimensions, err := newDimensions(dataPool, v.Input.Dimensions)
+	if err != nil {
+		return nil, err
+	}
+
+	o.fields["dimensions"] = dimensions
+	o.fields["settings"].(map[string]interface{})["filters"] = newFilters(v.Input.Filters)
+
+	return response("modifyMetric", s, o), nil
+}
+
+func migrateMetric(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.MigrateMetricInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	o, err := s.find(TypeMetric, v.Input.MetricId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.find(TypeDataPool, v.Input.NewDataPoolId); err != nil {
+		return nil, err
+	}
+
+	o.parent = v.Input.NewDataPoolId
+
+	return map[string]interface{}{"migrateMetric": s.render(o)}, nil
+}
+
+// Applications
+
+func createApplication(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.CreateApplicationInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	propeller := pc.PropellerP1XSmall
+	if v.Input.Propeller != nil {
+		propeller = *v.Input.Propeller
+	}
+
+	scopes := v.Input.Scopes
+	if scopes == nil {
+		scopes = []pc.ApplicationScope{}
+	}
+
+	o, err := s.createNamed(TypeApplication, v.Input.UniqueName, v.Input.Description, map[string]interface{}{
+		"propeller": propeller,
+		"scopes":    scopes,
+		"secret":    nil,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	o.fields["clientId"] = o.id
+
+	// The secret is only returned when the Application is created.
+	rendered := response("createApplication", s, o)
+	rendered["createApplication"].(map[string]interface{})["application"].(map[string]interface{})["secret"] = "secret-" + o.id
+
+	return rendered, nil
+}
+
+func modifyApplication(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.ModifyApplicationInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	if v.Input.IdOrUniqueName == nil {
+		return nil, newError("BAD_USER_INPUT", "idOrUniqueName must be set")
+	}
+
+	o, err := s.findByIdOrUniqueName(TypeApplication, v.Input.IdOrUniqueName.Id, v.Input.IdOrUniqueName.UniqueName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.modify(o, v.Input.UniqueName, v.Input.Description); err != nil {
+		return nil, err
+	}
+
+	if v.Input.Propeller != nil {
+		o.fields["propeller"] = *v.Input.Propeller
+	}
+
+	if v.Input.Scopes != nil {
+		o.fields["scopes"] = v.Input.Scopes
+	}
+
+	return response("modifyApplication", s, o), nil
+}
+
+func applicationByClientID(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		ClientId string `json:"clientId"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	for _, o := range s.objects {
+		if o.typename == TypeApplication && o.fields["clientId"] == v.ClientId {
+			return map[string]interface{}{"application": s.read(o)}, nil
+		}
+	}
+
+	return nil, notFound(TypeApplication, v.ClientId)
+}
+
+// Policies
+
+func createPolicy(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.CreatePolicyInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.find(TypeMetric, v.Input.Metric); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.find(TypeApplication, v.Input.Application); err != nil {
+		return nil, err
+	}
+
+	o := s.create(TypePolicy, map[string]interface{}{
+		"type":        v.Input.Type,
+		"metric":      map[string]interface{}{"id": v.Input.Metric},
+		"application": map[string]interface{}{"id": v.Input.Application},
+	})
+
+	return response("createPolicy", s, o), nil
+}
+
+func modifyPolicy(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.ModifyPolicyInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	o, err := s.find(TypePolicy, v.Input.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.modify(o, nil, nil); err != nil {
+		return nil, err
+	}
+
+	o.fields["type"] = v.Input.Type
+
+	return response("modifyPolicy", s, o), nil
+}
+
+// Boosters
+
+func createBooster(s *Server, variables json.RawMessage) (map[string]interface{}, error) {
+	var v struct {
+		Input pc.CreateBoosterInput `json:"input"`
+	}
+	if err := decode(variables, &v); err != nil {
+		return nil, err
+	}
+
+	metric, err := s.find(TypeMetric, v.Input.Metric)
+	if err != nil {
+		return nil, err
+	}
+
+	dataPool, err := s.find(TypeDataPool, metric.parent)
+	if err != nil {
+		return nil, err
+	}
+
+	dimensions, err := newDimensions(dataPool, v.Input.Dimensions)
+	if err != nil {
+		return nil, err
+	}
+
+	o := s.create(TypeBooster, map[string]interface{}{
+		"metric":          map[string]interface{}{"id": metric.id},
+		"status":          "CREATED",
+		"error":           nil,
+		"progress":        nil,
+		"dimensions":      dimensions,
+		"recordCount":     nil,
+		"sizeInTerabytes": nil,
+	})
+
+	return response("createBooster", s, o), nil
+}
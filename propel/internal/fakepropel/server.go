@@ -0,0 +1,638 @@
+// Package fakepropel implements an in-process fake of the Propel API. It serves the OAuth token endpoint and the GraphQL
+// operations used by the provider from an in-memory store, so that resources can be tested without a Propel account.
+package fakepropel
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+// The GraphQL type names of the objects stored by the fake.
+const (
+	TypeDataSource  = "DataSource"
+	TypeDataPool    = "DataPool"
+	TypeMetric      = "Metric"
+	TypeApplication = "Application"
+	TypePolicy      = "Policy"
+	TypeBooster     = "Booster"
+)
+
+const (
+	// TokenPath is the path of the OAuth token endpoint.
+	TokenPath = "/oauth2/token"
+	// GraphQLPath is the path of the GraphQL endpoint.
+	GraphQLPath = "/graphql"
+
+	accountID     = "ACC00000000000000000000000"
+	environmentID = "ENV00000000000000000000000"
+	user          = "fakepropel"
+)
+
+var idPrefixes = map[string]string{
+	TypeDataSource:  "DSO",
+	TypeDataPool:    "DPO",
+	TypeMetric:      "MET",
+	TypeApplication: "APP",
+	TypePolicy:      "POL",
+	TypeBooster:     "BOO",
+}
+
+// tableIDPrefix is the ID prefix of Data Source tables, which are stored with their Data Source.
+const tableIDPrefix = "TBL"
+
+// defaultTransitions are the statuses objects move through after they are created, one per read, when no transitions
+// were set with SetTransitions.
+var defaultTransitions = map[string][]string{
+	TypeDataSource: {"CONNECTING", "CONNECTED"},
+	TypeDataPool:   {"PENDING", "LIVE"},
+	TypeBooster:    {"OPTIMIZING", "LIVE"},
+}
+
+// Server is a fake Propel API listening on a local address.
+type Server struct {
+	*httptest.Server
+
+	// ClientID and ClientSecret are the only credentials accepted by the token endpoint.
+	ClientID     string
+	ClientSecret string
+
+	mu           sync.Mutex
+	lastID       int
+	accessTokens map[string]bool
+	objects      map[string]*object
+	transitions  map[string][]string
+	// dataSourceTransitions are the transitions of Data Sources of a given type, which take precedence over the
+	// transitions of all Data Sources.
+	dataSourceTransitions map[string][]string
+	// deletions are the statuses objects move through after they are deleted, before they no longer exist.
+	deletions map[string][]string
+	// errorMessages are the error messages reported by objects once they are FAILED.
+	errorMessages map[string]string
+	maxPageSize   int
+	requests      map[string]int
+}
+
+// object is an object stored by the fake. Its fields are kept in the shape the API returns them in.
+type object struct {
+	typename string
+	id       string
+	fields   map[string]interface{}
+	// parent is the ID of the object this one is built on, such as a Data Pool's Data Source. It is rendered when the
+	// object is read, so that changes to the parent are visible.
+	parent string
+	// statuses are the statuses the object still has to move through.
+	statuses []string
+	// deleted is whether the object was deleted, and no longer exists once it moved through its statuses.
+	deleted bool
+}
+
+// NewServer starts a fake Propel API. The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		ClientID:              "fake-client-id",
+		ClientSecret:          "fake-client-secret",
+		accessTokens:          map[string]bool{},
+		objects:               map[string]*object{},
+		transitions:           map[string][]string{},
+		dataSourceTransitions: map[string][]string{},
+		deletions:             map[string][]string{},
+		errorMessages:         map[string]string{},
+		requests:              map[string]int{},
+	}
+
+	for typename, statuses := range defaultTransitions {
+		s.transitions[typename] = statuses
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(TokenPath, s.handleToken)
+	mux.HandleFunc(GraphQLPath, s.handleGraphQL)
+
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// TokenURL returns the URL of the OAuth token endpoint.
+func (s *Server) TokenURL() string {
+	return s.URL + TokenPath
+}
+
+// GraphQLURL returns the URL of the GraphQL endpoint.
+func (s *Server) GraphQLURL() string {
+	return s.URL + GraphQLPath
+}
+
+// SetTransitions sets the statuses that objects of the given type move through after they are created. Each read of
+// an object advances it to the next status, and it keeps the last one. It only affects objects created afterwards.
+func (s *Server) SetTransitions(typename string, statuses ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.transitions[typename] = statuses
+}
+
+// SetDataSourceTransitions sets the statuses that Data Sources of the given type, such as S3, move through after they
+// are created or reconnected, in place of the ones set for all Data Sources with SetTransitions.
+func (s *Server) SetDataSourceTransitions(dataSourceType string, statuses ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dataSourceTransitions[dataSourceType] = statuses
+}
+
+// SetDeletionTransitions sets the statuses that objects of the given type move through after they are deleted, such
+// as DELETING. Each read of a deleted object advances it to the next status, and it no longer exists once it went
+// through all of them. Without deletion transitions, objects no longer exist as soon as they are deleted.
+func (s *Server) SetDeletionTransitions(typename string, statuses ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deletions[typename] = statuses
+}
+
+// SetErrorMessage sets the error message that objects of the given type report once they move to the FAILED status.
+func (s *Server) SetErrorMessage(typename, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errorMessages[typename] = message
+}
+
+// SetStatus sets the status of an existing object, discarding any transitions it still had to go through.
+func (s *Server) SetStatus(id, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if o, ok := s.objects[id]; ok {
+		o.fields["status"] = status
+		o.statuses = nil
+	}
+}
+
+// Remove deletes an object, as if it had been deleted outside of Terraform.
+func (s *Server) Remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.objects, id)
+}
+
+// AddDataPoolColumn adds a column to an existing Data Pool, as if it had been added outside of Terraform.
+func (s *Server) AddDataPoolColumn(id, name string, columnType pc.ColumnType, nullable bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if o, ok := s.objects[id]; ok && o.typename == TypeDataPool {
+		columns := o.fields["columns"].(map[string]interface{})
+		columns["nodes"] = append(columns["nodes"].([]interface{}), newDataPoolColumn(name, columnType, nullable))
+	}
+}
+
+// SetMaxPageSize caps the number of objects returned by list queries, so that pagination can be tested. Zero means
+// no cap.
+func (s *Server) SetMaxPageSize(size int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maxPageSize = size
+}
+
+// Get returns an object as the API would return it, or nil if it does not exist.
+func (s *Server) Get(id string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.objects[id]
+	if !ok {
+		return nil
+	}
+
+	return s.render(o)
+}
+
+// Requests returns the number of times the GraphQL operation was requested.
+func (s *Server) Requests(operationName string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[operationName]
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "client_credentials" {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "unsupported_grant_type"})
+		return
+	}
+
+	if r.PostForm.Get("client_id") != s.ClientID || r.PostForm.Get("client_secret") != s.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{"error": "invalid_client"})
+		return
+	}
+
+	s.mu.Lock()
+	s.lastID++
+	token := fmt.Sprintf("fake-access-token-%d", s.lastID)
+	s.accessTokens[token] = true
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	})
+}
+
+type graphQLRequest struct {
+	OperationName string          `json:"operationName"`
+	Variables     json.RawMessage `json:"variables"`
+}
+
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !s.accessTokens[token] {
+		writeJSON(w, http.StatusUnauthorized, errorResponse(newError("UNAUTHENTICATED", "invalid or missing access token")))
+		return
+	}
+
+	var req graphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse(newError("BAD_REQUEST", err.Error())))
+		return
+	}
+
+	op, ok := operations[req.OperationName]
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, errorResponse(newError("GRAPHQL_VALIDATION_FAILED", fmt.Sprintf("unknown operation %q", req.OperationName))))
+		return
+	}
+
+	s.requests[req.OperationName]++
+
+	data, err := op(s, req.Variables)
+	if err != nil {
+		writeJSON(w, http.StatusOK, errorResponse(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+// apiError is a GraphQL error returned by the fake.
+type apiError struct {
+	Message    string                 `json:"message"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (e *apiError) Error() string {
+	return e.Message
+}
+
+func newError(code, message string) *apiError {
+	return &apiError{
+		Message:    message,
+		Extensions: map[string]interface{}{"code": code},
+	}
+}
+
+func notFound(typename, key string) *apiError {
+	return newError("NOT_FOUND", fmt.Sprintf("%s %q not found", typename, key))
+}
+
+func errorResponse(err error) map[string]interface{} {
+	gqlErr, ok := err.(*apiError)
+	if !ok {
+		gqlErr = &apiError{Message: err.Error()}
+	}
+
+	return map[string]interface{}{
+		"data":   nil,
+		"errors": []*apiError{gqlErr},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// create stores a new object of the given type.
+func (s *Server) newID(prefix string) string {
+	s.lastID++
+
+	return fmt.Sprintf("%s%023d", prefix, s.lastID)
+}
+
+func (s *Server) create(typename string, fields map[string]interface{}) *object {
+	id := s.newID(idPrefixes[typename])
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	fields["id"] = id
+	fields["account"] = map[string]interface{}{"id": accountID}
+	fields["environment"] = map[string]interface{}{"id": environmentID}
+	fields["createdAt"] = now
+	fields["modifiedAt"] = now
+	fields["createdBy"] = user
+	fields["modifiedBy"] = user
+
+	o := &object{
+		typename: typename,
+		id:       id,
+		fields:   fields,
+	}
+
+	if _, ok := fields["status"]; ok {
+		o.statuses = s.transitionsOf(o)
+	}
+
+	s.objects[id] = o
+
+	return o
+}
+
+// transitionsOf returns a copy of the statuses a new or reconnected object moves through.
+func (s *Server) transitionsOf(o *object) []string {
+	statuses := s.transitions[o.typename]
+	if o.typename == TypeDataSource {
+		if dataSourceType, ok := o.fields["type"].(string); ok {
+			if ds, ok := s.dataSourceTransitions[dataSourceType]; ok {
+				statuses = ds
+			}
+		}
+	}
+
+	return append([]string(nil), statuses...)
+}
+
+// createNamed stores a new object of a type that has a unique name and a description. The unique name must not be
+// used by another object of the same type, and defaults to the ID.
+func (s *Server) createNamed(typename string, uniqueName, description *string, fields map[string]interface{}) (*object, error) {
+	if uniqueName != nil && *uniqueName != "" {
+		if _, err := s.findByName(typename, *uniqueName); err == nil {
+			return nil, newError("ALREADY_EXISTS", fmt.Sprintf("%s with unique name %q already exists", typename, *uniqueName))
+		}
+	}
+
+	o := s.create(typename, fields)
+
+	o.fields["uniqueName"] = o.id
+	if uniqueName != nil && *uniqueName != "" {
+		o.fields["uniqueName"] = *uniqueName
+	}
+
+	o.fields["description"] = ""
+	if description != nil {
+		o.fields["description"] = *description
+	}
+
+	return o, nil
+}
+
+// find returns the object of the given type with the given ID.
+func (s *Server) find(typename, id string) (*object, error) {
+	o, ok := s.objects[id]
+	if !ok || o.typename != typename {
+		return nil, notFound(typename, id)
+	}
+
+	if o.deleted && len(o.statuses) == 0 {
+		delete(s.objects, id)
+		return nil, notFound(typename, id)
+	}
+
+	return o, nil
+}
+
+func (s *Server) findByName(typename, uniqueName string) (*object, error) {
+	for _, o := range s.objects {
+		if o.typename == typename && o.fields["uniqueName"] == uniqueName {
+			return o, nil
+		}
+	}
+
+	return nil, notFound(typename, uniqueName)
+}
+
+// findByIdOrUniqueName returns the object referenced by an IdOrUniqueName input.
+func (s *Server) findByIdOrUniqueName(typename string, id, uniqueName *string) (*object, error) {
+	switch {
+	case id != nil:
+		return s.find(typename, *id)
+	case uniqueName != nil:
+		return s.findByName(typename, *uniqueName)
+	}
+
+	return nil, newError("BAD_USER_INPUT", "either id or uniqueName must be set")
+}
+
+// read renders an object after moving it on to its next status, as a client polling the API would see it.
+func (s *Server) read(o *object) map[string]interface{} {
+	if len(o.statuses) > 0 {
+		o.fields["status"] = o.statuses[0]
+		o.statuses = o.statuses[1:]
+	}
+
+	if message, ok := s.errorMessages[o.typename]; ok && o.fields["status"] == "FAILED" {
+		o.fields["error"] = map[string]interface{}{"message": message}
+	}
+
+	return s.render(o)
+}
+
+// modify marks an object as modified and applies the unique name and description changes common to all modify
+// mutations.
+func (s *Server) modify(o *object, uniqueName *string, description *string) error {
+	if uniqueName != nil && *uniqueName != o.fields["uniqueName"] {
+		if _, err := s.findByName(o.typename, *uniqueName); err == nil {
+			return newError("ALREADY_EXISTS", fmt.Sprintf("%s with unique name %q already exists", o.typename, *uniqueName))
+		}
+
+		o.fields["uniqueName"] = *uniqueName
+	}
+
+	if description != nil {
+		o.fields["description"] = *description
+	}
+
+	o.fields["modifiedAt"] = time.Now().UTC().Format(time.RFC3339)
+	o.fields["modifiedBy"] = user
+
+	return nil
+}
+
+func (s *Server) delete(typename, id string) error {
+	o, err := s.find(typename, id)
+	if err != nil {
+		return err
+	}
+
+	if statuses := s.deletions[typename]; len(statuses) > 0 {
+		o.statuses = append([]string(nil), statuses...)
+		o.deleted = true
+		return nil
+	}
+
+	delete(s.objects, id)
+
+	return nil
+}
+
+// render returns an object in the shape the API returns it in, including the object it is built on.
+func (s *Server) render(o *object) map[string]interface{} {
+	rendered := make(map[string]interface{}, len(o.fields)+2)
+	for key, value := range o.fields {
+		rendered[key] = value
+	}
+
+	rendered["__typename"] = o.typename
+
+	// Like the API, the fake only returns the first page of a Data Source's tables and their columns, and of a Data
+	// Pool's columns.
+	switch o.typename {
+	case TypeDataSource:
+		rendered["tables"] = s.tables(o, nil, nil)
+	case TypeDataPool:
+		rendered["columns"] = s.connection(o.fields["columns"].(map[string]interface{})["nodes"].([]interface{}), nil, nil)
+	}
+
+	if o.parent != "" {
+		parent := map[string]interface{}{"id": o.parent}
+		if p, ok := s.objects[o.parent]; ok {
+			parent = s.render(p)
+		}
+
+		switch o.typename {
+		case TypeDataPool:
+			rendered["dataSource"] = parent
+		case TypeMetric:
+			rendered["dataPool"] = parent
+		}
+	}
+
+	return rendered
+}
+
+// list returns a page of the objects of the given type, ordered by ID, as a connection.
+func (s *Server) list(typename string, first *int, after *string) map[string]interface{} {
+	var ids []string
+	for id, o := range s.objects {
+		if o.typename == typename && (after == nil || id > *after) {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	size := len(ids)
+	if first != nil && *first < size {
+		size = *first
+	}
+
+	if s.maxPageSize > 0 && s.maxPageSize < size {
+		size = s.maxPageSize
+	}
+
+	edges := make([]interface{}, 0, size)
+	nodes := make([]interface{}, 0, size)
+	for _, id := range ids[:size] {
+		node := s.render(s.objects[id])
+		edges = append(edges, map[string]interface{}{"cursor": id, "node": node})
+		nodes = append(nodes, node)
+	}
+
+	pageInfo := map[string]interface{}{
+		"startCursor":     nil,
+		"endCursor":       nil,
+		"hasNextPage":     size < len(ids),
+		"hasPreviousPage": after != nil,
+	}
+
+	if size > 0 {
+		pageInfo["startCursor"] = ids[0]
+		pageInfo["endCursor"] = ids[size-1]
+	}
+
+	return map[string]interface{}{
+		"pageInfo": pageInfo,
+		"edges":    edges,
+		"nodes":    nodes,
+	}
+}
+
+// connection returns a page of nodes nested in an object, such as a Data Source's tables or a table's columns, as a
+// connection. The nodes' positions are their cursors.
+func (s *Server) connection(nodes []interface{}, first *int, after *string) map[string]interface{} {
+	start := 0
+	if after != nil {
+		position, err := strconv.Atoi(*after)
+		if err != nil {
+			position = len(nodes)
+		}
+
+		start = position + 1
+	}
+
+	if start > len(nodes) {
+		start = len(nodes)
+	}
+
+	size := len(nodes) - start
+	if first != nil && *first < size {
+		size = *first
+	}
+
+	if s.maxPageSize > 0 && s.maxPageSize < size {
+		size = s.maxPageSize
+	}
+
+	edges := make([]interface{}, 0, size)
+	for i, node := range nodes[start : start+size] {
+		edges = append(edges, map[string]interface{}{"cursor": strconv.Itoa(start + i), "node": node})
+	}
+
+	pageInfo := map[string]interface{}{
+		"startCursor":     nil,
+		"endCursor":       nil,
+		"hasNextPage":     start+size < len(nodes),
+		"hasPreviousPage": start > 0,
+	}
+
+	if size > 0 {
+		pageInfo["startCursor"] = strconv.Itoa(start)
+		pageInfo["endCursor"] = strconv.Itoa(start + size - 1)
+	}
+
+	return map[string]interface{}{
+		"pageInfo": pageInfo,
+		"edges":    edges,
+		"nodes":    nodes[start : start+size],
+	}
+}
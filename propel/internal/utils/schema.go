@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DatasourceSchemaFromResourceSchema converts a resource schema into a data source schema
+// where every attribute is computed. Computed nested blocks become attributes, which cannot
+// have sensitive fields, so an attribute with a sensitive field is sensitive as a whole.
+func DatasourceSchemaFromResourceSchema(rs map[string]*schema.Schema) map[string]*schema.Schema {
+	ds := make(map[string]*schema.Schema, len(rs))
+
+	for k, v := range rs {
+		dv := &schema.Schema{
+			Type:        v.Type,
+			Computed:    true,
+			Sensitive:   v.Sensitive,
+			Description: v.Description,
+		}
+
+		switch elem := v.Elem.(type) {
+		case *schema.Resource:
+			dv.Elem = &schema.Resource{
+				Schema: DatasourceSchemaFromResourceSchema(elem.Schema),
+			}
+			dv.Sensitive = dv.Sensitive || hasSensitive(elem.Schema)
+		case *schema.Schema:
+			dv.Elem = &schema.Schema{Type: elem.Type}
+		}
+
+		ds[k] = dv
+	}
+
+	return ds
+}
+
+// hasSensitive returns whether any of the fields in a schema, or in its nested blocks, is sensitive.
+func hasSensitive(s map[string]*schema.Schema) bool {
+	for _, v := range s {
+		if v.Sensitive {
+			return true
+		}
+
+		if elem, ok := v.Elem.(*schema.Resource); ok && hasSensitive(elem.Schema) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DatasourceIdFromIds returns a stable ID for a data source that lists the given objects.
+func DatasourceIdFromIds(ids []string) string {
+	return strconv.Itoa(schema.HashString(strings.Join(ids, ",")))
+}
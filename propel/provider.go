@@ -4,16 +4,29 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/propeldata/terraform-provider-propel/propel/internal/utils"
 	pc "github.com/propeldata/terraform-provider-propel/propel_client"
 )
 
+// Options customizes the provider returned by NewWithOptions.
+type Options struct {
+	// Cassette, when set, records or replays the provider's exchanges with the Propel API.
+	Cassette *pc.Cassette
+}
+
 // Provider -
 func Provider() *schema.Provider {
+	return NewWithOptions(Options{})
+}
+
+// NewWithOptions returns the provider, customized with the given options.
+func NewWithOptions(options Options) *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"client_id": {
@@ -30,17 +43,96 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("PROPEL_CLIENT_SECRET", nil),
 				Description: "Your Propel Application's secret.",
 			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      pc.DefaultRegion,
+				ValidateFunc: validation.StringInSlice(pc.Regions, false),
+				Description:  "The Propel region to connect to. Used to derive the API and OAuth URLs when they are not set.",
+			},
+			"api_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PROPEL_API_URL", nil),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "The URL of the Propel GraphQL API. Overrides the URL derived from `region`.",
+			},
+			"oauth_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PROPEL_OAUTH_URL", nil),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "The URL of the Propel OAuth token endpoint. Overrides the URL derived from `region`.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      pc.DefaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of times a throttled or failed request to the Propel API is retried. Mutations are only retried when they were throttled. Set to 0 to disable retries.",
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(pc.DefaultRetryMaxWait / time.Second),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of seconds to wait between retries.",
+			},
+			"http_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(pc.DefaultTimeout / time.Second),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of seconds a request to the Propel API may take, including retries.",
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+				Description:  "The URL of a proxy to send requests through. Defaults to the `HTTPS_PROXY` environment variable.",
+			},
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PROPEL_CA_CERT_FILE", nil),
+				Description: "The path to a PEM bundle of certificate authorities to trust in addition to the system roots, such as a corporate proxy's CA.",
+			},
+			"client_cert_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key_file"},
+				Description:  "The path to a PEM client certificate to present for mTLS.",
+			},
+			"client_key_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_cert_file"},
+				Description:  "The path to the PEM private key of the mTLS client certificate.",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"propel_data_source": resourceDataSource(),
 			"propel_data_pool":   resourceDataPool(),
 			"propel_metric":      resourceMetric(),
+			"propel_application": resourceApplication(),
+			"propel_policy":      resourcePolicy(),
+			"propel_booster":     resourceBooster(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"propel_data_source":  dataSourceDataSource(),
+			"propel_data_pool":    dataSourceDataPool(),
+			"propel_metric":       dataSourceMetric(),
+			"propel_data_sources": dataSourceDataSources(),
+			"propel_data_pools":   dataSourceDataPools(),
+			"propel_metrics":      dataSourceMetrics(),
+		},
+		ConfigureContextFunc: func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+			return providerConfigure(ctx, d, options)
 		},
-		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData, options Options) (interface{}, diag.Diagnostics) {
 	clientID := d.Get("client_id").(string)
 	clientSecret := d.Get("client_secret").(string)
 
@@ -64,9 +156,23 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		runtime.GOARCH,
 	))
 
-	c, err := pc.NewPropelClient(clientID, clientSecret, userAgent)
+	opts := pc.ClientOptions{
+		Region:         d.Get("region").(string),
+		ApiURL:         d.Get("api_url").(string),
+		OAuthURL:       d.Get("oauth_url").(string),
+		MaxRetries:     d.Get("max_retries").(int),
+		RetryMaxWait:   time.Duration(d.Get("retry_max_wait").(int)) * time.Second,
+		Timeout:        time.Duration(d.Get("http_timeout").(int)) * time.Second,
+		ProxyURL:       d.Get("proxy_url").(string),
+		CACertFile:     d.Get("ca_cert_file").(string),
+		ClientCertFile: d.Get("client_cert_file").(string),
+		ClientKeyFile:  d.Get("client_key_file").(string),
+		Cassette:       options.Cassette,
+	}
+
+	c, err := pc.NewPropelClient(clientID, clientSecret, userAgent, opts)
 	if err != nil {
-		return nil, diag.FromErr(err)
+		return nil, diagFromErr(err)
 	}
 
 	return c, nil
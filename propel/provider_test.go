@@ -2,14 +2,26 @@ package propel
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"io/fs"
 	"log"
+	"math/rand"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/propeldata/terraform-provider-propel/propel/internal/fakepropel"
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
 )
 
 var (
@@ -37,6 +49,8 @@ func TestProvider_impl(t *testing.T) {
 }
 
 func testAccPreCheck(t *testing.T) {
+	testAccCassette(t)
+
 	if err := os.Getenv("PROPEL_CLIENT_ID"); err == "" {
 		t.Fatal("PROPEL_CLIENT_ID must be set for acceptance tests")
 	}
@@ -50,6 +64,150 @@ func testAccPreCheck(t *testing.T) {
 	}
 }
 
+// testUnitFakePropel starts a fake Propel API for the duration of a unit test and points the provider at it. It returns
+// the fake and provider factories that configure a new provider against it.
+func testUnitFakePropel(t *testing.T) (*fakepropel.Server, map[string]func() (*schema.Provider, error)) {
+	server := fakepropel.NewServer()
+	t.Cleanup(server.Close)
+
+	t.Setenv("PROPEL_CLIENT_ID", server.ClientID)
+	t.Setenv("PROPEL_CLIENT_SECRET", server.ClientSecret)
+	t.Setenv("PROPEL_API_URL", server.GraphQLURL())
+	t.Setenv("PROPEL_OAUTH_URL", server.TokenURL())
+
+	testShortenWaits(t)
+
+	return server, map[string]func() (*schema.Provider, error){
+		"propel": func() (*schema.Provider, error) {
+			return Provider(), nil
+		},
+	}
+}
+
+// testShortenWaits makes the provider poll without delay for the duration of a test that does not talk to the real
+// Propel API.
+func testShortenWaits(t *testing.T) {
+	delay, minTimeout, pollInterval := waitDelay, waitMinTimeout, deletionPollInterval
+	waitDelay, waitMinTimeout, deletionPollInterval = 0, 10*time.Millisecond, 10*time.Millisecond
+	t.Cleanup(func() {
+		waitDelay, waitMinTimeout, deletionPollInterval = delay, minTimeout, pollInterval
+	})
+}
+
+// testAccCassette records the acceptance test's exchanges with the Propel API to a cassette, or replays them from
+// one, depending on PROPEL_CASSETTE_MODE. Cassettes are kept in testdata/cassettes, one per test. When replaying,
+// no credentials are needed and tests without a cassette are skipped.
+func testAccCassette(t *testing.T) {
+	path := filepath.Join("testdata", "cassettes", t.Name()+".json")
+
+	var cassette *pc.Cassette
+
+	switch mode := pc.CassetteMode(os.Getenv("PROPEL_CASSETTE_MODE")); mode {
+	case "":
+		return
+	case pc.CassetteModeRecord:
+		cassette = pc.NewCassette(path)
+		t.Cleanup(func() {
+			if err := cassette.Save(); err != nil {
+				t.Errorf("unable to save cassette: %s", err)
+			}
+		})
+	case pc.CassetteModeReplay:
+		c, err := pc.LoadCassette(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			t.Skipf("No cassette recorded at %s; record one with PROPEL_CASSETTE_MODE=record", path)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cassette = c
+
+		// The credentials were scrubbed from the cassette, so any will do.
+		t.Setenv("PROPEL_CLIENT_ID", "replay")
+		t.Setenv("PROPEL_CLIENT_SECRET", "replay")
+
+		testShortenWaits(t)
+	default:
+		t.Fatalf("PROPEL_CASSETTE_MODE must be %q or %q, got %q", pc.CassetteModeRecord, pc.CassetteModeReplay, mode)
+	}
+
+	// testAccProviderFactories return testAccProvider, so the test's steps use the provider with the cassette.
+	provider := testAccProvider
+	testAccProvider = NewWithOptions(Options{Cassette: cassette})
+	t.Cleanup(func() {
+		testAccProvider = provider
+	})
+}
+
+var (
+	testAccRandsMu sync.Mutex
+	testAccRands   = map[string]*rand.Rand{}
+)
+
+// testAccRandString returns a random string of the given length. When recording or replaying cassettes, the string
+// is derived from the test name instead, so that the replayed requests match the recorded ones.
+func testAccRandString(t *testing.T, n int) string {
+	if os.Getenv("PROPEL_CASSETTE_MODE") == "" {
+		return acctest.RandString(n)
+	}
+
+	testAccRandsMu.Lock()
+	defer testAccRandsMu.Unlock()
+
+	r, ok := testAccRands[t.Name()]
+	if !ok {
+		h := fnv.New64a()
+		h.Write([]byte(t.Name()))
+		r = rand.New(rand.NewSource(int64(h.Sum64())))
+		testAccRands[t.Name()] = r
+	}
+
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = acctest.CharSetAlpha[r.Intn(len(acctest.CharSetAlpha))]
+	}
+
+	return string(b)
+}
+
+// testAccSecret returns the given secret, or the placeholder that cassettes scrub secrets to when recording or
+// replaying them, so that secrets read back from the Propel API replay without changes.
+func testAccSecret(secret string) string {
+	if os.Getenv("PROPEL_CASSETTE_MODE") == "" {
+		return secret
+	}
+
+	return pc.CassetteSecret
+}
+
+// testUnitCheckDestroy checks that the fake Propel API no longer has any of the resources of the given type.
+func testUnitCheckDestroy(server *fakepropel.Server, resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type == resourceType && server.Get(rs.Primary.ID) != nil {
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+// testUnitStoreID stores the ID of a resource, so that a later step can refer to it.
+func testUnitStoreID(n string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		*id = rs.Primary.ID
+
+		return nil
+	}
+}
+
 func skipIfEnvNotSet(t *testing.T, env string) {
 	if t == nil {
 		log.Println("[DEBUG] Not running inside of test")
@@ -0,0 +1,277 @@
+package propel
+
+import (
+	"context"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+func resourceApplication() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceApplicationCreate,
+		ReadContext:   resourceApplicationRead,
+		UpdateContext: resourceApplicationUpdate,
+		DeleteContext: resourceApplicationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceApplicationImport,
+		},
+		Description: "Provides a Propel Application resource. This can be used to create and manage Propel Applications.",
+		Schema: map[string]*schema.Schema{
+			"unique_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The Application's name.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The Application's description.",
+			},
+			"propeller": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"P1_X_SMALL",
+					"P1_SMALL",
+					"P1_MEDIUM",
+					"P1_LARGE",
+					"P1_X_LARGE",
+				}, false),
+				Description: "The Application's Propeller. If no Propeller is provided, Propel will set the Propeller to `P1_X_SMALL`.",
+			},
+			"scopes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						"ADMIN",
+						"METRIC_QUERY",
+						"METRIC_STATS",
+					}, false),
+				},
+				Description: "The Application's API authorization scopes. If not specified, all scopes will be granted to the Application.",
+			},
+			"account": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Account that the Application belongs to.",
+			},
+			"environment": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Environment that the Application belongs to.",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Application's OAuth 2.0 client identifier.",
+			},
+			"secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The Application's OAuth 2.0 client secret.",
+			},
+		},
+	}
+}
+
+func resourceApplicationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	var diags diag.Diagnostics
+
+	uniqueName := d.Get("unique_name").(string)
+	description := d.Get("description").(string)
+	input := &pc.CreateApplicationInput{
+		UniqueName:  &uniqueName,
+		Description: &description,
+	}
+
+	if def, ok := d.GetOk("propeller"); ok {
+		propeller := pc.Propeller(def.(string))
+		input.Propeller = &propeller
+	}
+
+	if def, ok := d.GetOk("scopes"); ok {
+		input.Scopes = expandApplicationScopes(def.(*schema.Set).List())
+	}
+
+	response, err := pc.CreateApplication(ctx, c, input)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	switch r := (*response.GetCreateApplication()).(type) {
+	case *pc.CreateApplicationCreateApplicationApplicationResponse:
+		d.SetId(r.Application.Id)
+
+		// The secret is only guaranteed to be returned when the Application is created.
+		if r.Application.Secret != nil {
+			if err := d.Set("secret", *r.Application.Secret); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		return resourceApplicationRead(ctx, d, meta)
+	case *pc.CreateApplicationCreateApplicationFailureResponse:
+		diags = append(diags, diagFromFailure("Failed to create Application", r.Error.Code, r.Error.Message)...)
+	}
+
+	return diags
+}
+
+func resourceApplicationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	var diags diag.Diagnostics
+
+	response, err := pc.Application(ctx, c, d.Id())
+	if err != nil {
+		if pc.IsNotFound(err) {
+			tflog.Warn(ctx, "Application not found, removing from state", map[string]interface{}{"id": d.Id()})
+			d.SetId("")
+			return nil
+		}
+
+		return diagFromErr(err)
+	}
+
+	if response.Application == nil {
+		tflog.Warn(ctx, "Application not found, removing from state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(response.Application.Id)
+	if err := d.Set("unique_name", response.Application.UniqueName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("description", response.Application.Description); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("propeller", response.Application.Propeller); err != nil {
+		return diag.FromErr(err)
+	}
+
+	scopes := make([]string, 0, len(response.Application.Scopes))
+	for _, scope := range response.Application.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	if err := d.Set("scopes", scopes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("account", response.Application.Account.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("environment", response.Application.Environment.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("client_id", response.Application.ClientId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if response.Application.Secret != nil {
+		if err := d.Set("secret", *response.Application.Secret); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func resourceApplicationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	if d.HasChanges("unique_name", "description", "propeller", "scopes") {
+		id := d.Id()
+		uniqueName := d.Get("unique_name").(string)
+		description := d.Get("description").(string)
+		input := &pc.ModifyApplicationInput{
+			IdOrUniqueName: &pc.IdOrUniqueName{
+				Id: &id,
+			},
+			UniqueName:  &uniqueName,
+			Description: &description,
+		}
+
+		if d.HasChange("propeller") {
+			propeller := pc.Propeller(d.Get("propeller").(string))
+			input.Propeller = &propeller
+		}
+
+		if d.HasChange("scopes") {
+			input.Scopes = expandApplicationScopes(d.Get("scopes").(*schema.Set).List())
+		}
+
+		response, err := pc.ModifyApplication(ctx, c, input)
+		if err != nil {
+			return diagFromErr(err)
+		}
+
+		if r, ok := (*response.GetModifyApplication()).(*pc.ModifyApplicationModifyApplicationFailureResponse); ok {
+			return diagFromFailure("Failed to update Application", r.Error.Code, r.Error.Message)
+		}
+	}
+
+	return resourceApplicationRead(ctx, d, meta)
+}
+
+func resourceApplicationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	_, err := pc.DeleteApplication(ctx, c, d.Id())
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourceApplicationImport accepts either an Application's ID or its unique name, which may be prefixed with "name:".
+func resourceApplicationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return importByIdOrUniqueName(ctx, d, meta, "Application", func(ctx context.Context, c graphql.Client, id string) (string, error) {
+		response, err := pc.Application(ctx, c, id)
+		if err != nil || response.Application == nil {
+			return "", err
+		}
+
+		return response.Application.Id, nil
+	}, func(ctx context.Context, c graphql.Client, uniqueName string) (string, error) {
+		response, err := pc.ApplicationByName(ctx, c, uniqueName)
+		if err != nil || response.Application == nil {
+			return "", err
+		}
+
+		return response.Application.Id, nil
+	})
+}
+
+func expandApplicationScopes(def []interface{}) []pc.ApplicationScope {
+	scopes := make([]pc.ApplicationScope, 0, len(def))
+
+	for _, rawScope := range def {
+		scopes = append(scopes, pc.ApplicationScope(rawScope.(string)))
+	}
+
+	return scopes
+}
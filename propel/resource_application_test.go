@@ -0,0 +1,183 @@
+package propel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+func TestAccPropelApplicationBasic(t *testing.T) {
+	ctx := map[string]interface{}{
+		"unique_name": acctest.RandString(10),
+		"propeller":   "P1_X_SMALL",
+	}
+
+	updatedCtx := map[string]interface{}{
+		"unique_name": ctx["unique_name"],
+		"propeller":   "P1_SMALL",
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckPropelApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPropelApplicationConfigBasic(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPropelApplicationExists("propel_application.foo"),
+					resource.TestCheckResourceAttr("propel_application.foo", "propeller", "P1_X_SMALL"),
+					resource.TestCheckResourceAttr("propel_application.foo", "scopes.#", "1"),
+					resource.TestCheckResourceAttrSet("propel_application.foo", "client_id"),
+					resource.TestCheckResourceAttrSet("propel_application.foo", "secret"),
+				),
+			},
+			{
+				Config: testAccCheckPropelApplicationConfigBasic(updatedCtx),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPropelApplicationExists("propel_application.foo"),
+					resource.TestCheckResourceAttr("propel_application.foo", "propeller", "P1_SMALL"),
+				),
+			},
+			{
+				ResourceName:            "propel_application.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret"},
+			},
+		},
+	})
+}
+
+func TestUnitPropelApplicationBasic(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+
+	ctx := map[string]interface{}{
+		"unique_name": acctest.RandString(10),
+		"propeller":   "P1_X_SMALL",
+		"scopes":      `["METRIC_QUERY"]`,
+	}
+
+	updatedCtx := map[string]interface{}{
+		"unique_name": ctx["unique_name"],
+		"propeller":   "P1_SMALL",
+		"scopes":      `["METRIC_QUERY", "METRIC_STATS"]`,
+	}
+
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_application"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitPropelApplicationConfig(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPropelApplicationExists("propel_application.foo"),
+					testUnitStoreID("propel_application.foo", &id),
+					resource.TestCheckResourceAttr("propel_application.foo", "propeller", "P1_X_SMALL"),
+					resource.TestCheckResourceAttr("propel_application.foo", "scopes.#", "1"),
+					resource.TestCheckResourceAttrSet("propel_application.foo", "client_id"),
+					resource.TestCheckResourceAttrSet("propel_application.foo", "secret"),
+				),
+			},
+			{
+				// Changing the Propeller and the scopes modifies the Application in place.
+				Config: testUnitPropelApplicationConfig(updatedCtx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("propel_application.foo", "id", &id),
+					resource.TestCheckResourceAttr("propel_application.foo", "propeller", "P1_SMALL"),
+					resource.TestCheckResourceAttr("propel_application.foo", "scopes.#", "2"),
+					resource.TestCheckTypeSetElemAttr("propel_application.foo", "scopes.*", "METRIC_STATS"),
+					resource.TestCheckResourceAttrSet("propel_application.foo", "secret"),
+				),
+			},
+			{
+				ResourceName:      "propel_application.foo",
+				ImportState:       true,
+				ImportStateId:     "name:" + ctx["unique_name"].(string),
+				ImportStateVerify: true,
+				// The secret is only returned when the Application is created.
+				ImportStateVerifyIgnore: []string{"secret"},
+			},
+			{
+				// An Application deleted outside of Terraform is created again.
+				PreConfig: func() {
+					server.Remove(id)
+				},
+				Config: testUnitPropelApplicationConfig(updatedCtx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_application.foo", "propeller", "P1_SMALL"),
+					func(*terraform.State) error {
+						if n := server.Requests("CreateApplication"); n != 2 {
+							return fmt.Errorf("expected the Application to be created twice, got %d", n)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testUnitPropelApplicationConfig(ctx map[string]interface{}) string {
+	return Nprintf(`
+	resource "propel_application" "foo" {
+		unique_name = "%{unique_name}"
+		propeller = "%{propeller}"
+		scopes = %{scopes}
+	}`, ctx)
+}
+
+func testAccCheckPropelApplicationConfigBasic(ctx map[string]interface{}) string {
+	return Nprintf(`
+	resource "propel_application" "foo" {
+		unique_name = "%{unique_name}"
+		propeller = "%{propeller}"
+		scopes = ["METRIC_QUERY"]
+	}`, ctx)
+}
+
+func testAccCheckPropelApplicationDestroy(s *terraform.State) error {
+	c := testAccProvider.Meta().(graphql.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "propel_application" {
+			continue
+		}
+
+		applicationID := rs.Primary.ID
+
+		_, err := pc.DeleteApplication(context.Background(), c, applicationID)
+		if err != nil && !pc.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPropelApplicationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("no Application ID set")
+		}
+
+		return nil
+	}
+}
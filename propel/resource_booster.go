@@ -0,0 +1,262 @@
+package propel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+// boosterStatusDeleted is not a Propel Booster status; it is used to signal that a deleted Booster no longer exists.
+const boosterStatusDeleted = "DELETED"
+
+func resourceBooster() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBoosterCreate,
+		ReadContext:   resourceBoosterRead,
+		DeleteContext: resourceBoosterDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Description: "Provides a Propel Booster resource. This can be used to create and manage Propel Boosters, which significantly improve the query performance for a Metric.",
+		Schema: map[string]*schema.Schema{
+			"metric": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The Metric that the Booster is associated to.",
+			},
+			"dimensions": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "The names of the columns to include in the Booster. Specify Dimensions in descending order of importance for filtering and in ascending order of cardinality.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Booster's status.",
+			},
+			"account": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Account that the Booster belongs to.",
+			},
+			"environment": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Environment that the Booster belongs to.",
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+func resourceBoosterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	input := &pc.CreateBoosterInput{
+		Metric:     d.Get("metric").(string),
+		Dimensions: expandBoosterDimensions(d.Get("dimensions").([]interface{})),
+	}
+
+	response, err := pc.CreateBooster(ctx, c, input)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId(response.CreateBooster.Booster.Id)
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	err = waitForBoosterLive(ctx, c, d.Id(), timeout)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	return resourceBoosterRead(ctx, d, meta)
+}
+
+func resourceBoosterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	var diags diag.Diagnostics
+
+	response, err := pc.Booster(ctx, c, d.Id())
+	if err != nil {
+		if pc.IsNotFound(err) {
+			tflog.Warn(ctx, "Booster not found, removing from state", map[string]interface{}{"id": d.Id()})
+			d.SetId("")
+			return nil
+		}
+
+		return diagFromErr(err)
+	}
+
+	if response.Booster == nil {
+		tflog.Warn(ctx, "Booster not found, removing from state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(response.Booster.Id)
+	if err := d.Set("metric", response.Booster.Metric.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	dimensions := make([]string, 0, len(response.Booster.Dimensions))
+	for _, dimension := range response.Booster.Dimensions {
+		dimensions = append(dimensions, dimension.ColumnName)
+	}
+
+	if err := d.Set("dimensions", dimensions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("status", response.Booster.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("account", response.Booster.Account.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("environment", response.Booster.Environment.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceBoosterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	_, err := pc.DeleteBooster(ctx, c, d.Id())
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waitForBoosterDeletion(ctx, c, d.Id(), timeout)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func waitForBoosterLive(ctx context.Context, client graphql.Client, id string, timeout time.Duration) error {
+	createStateConf := &resource.StateChangeConf{
+		Pending: []string{
+			string(pc.BoosterStatusCreated),
+			string(pc.BoosterStatusOptimizing),
+		},
+		Target: []string{
+			string(pc.BoosterStatusLive),
+		},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := pc.Booster(ctx, client, id)
+			if err != nil {
+				return nil, "", fmt.Errorf("error trying to read Booster status: %w", err)
+			}
+
+			if resp.Booster == nil {
+				return nil, "", fmt.Errorf("Booster %s not found", id)
+			}
+
+			switch resp.Booster.Status {
+			case pc.BoosterStatusOptimizing:
+				if resp.Booster.Progress != nil {
+					tflog.Debug(ctx, "Booster is optimizing", map[string]interface{}{
+						"id":       id,
+						"progress": fmt.Sprintf("%.0f%%", *resp.Booster.Progress*100),
+					})
+				}
+			case pc.BoosterStatusFailed:
+				message := "unknown error"
+				if resp.Booster.Error != nil {
+					message = resp.Booster.Error.Message
+				}
+
+				return resp, string(resp.Booster.Status), fmt.Errorf("Booster failed to set up: %s", message)
+			}
+
+			return resp, string(resp.Booster.Status), nil
+		},
+		Timeout:                   timeout - time.Minute,
+		Delay:                     waitDelay,
+		MinTimeout:                waitMinTimeout,
+		ContinuousTargetOccurence: 3,
+	}
+
+	_, err := createStateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for Booster to be LIVE: %w", err)
+	}
+
+	return nil
+}
+
+func waitForBoosterDeletion(ctx context.Context, client graphql.Client, id string, timeout time.Duration) error {
+	deleteStateConf := &resource.StateChangeConf{
+		Pending: []string{
+			string(pc.BoosterStatusDeleting),
+		},
+		Target: []string{
+			boosterStatusDeleted,
+		},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := pc.Booster(ctx, client, id)
+			if err != nil {
+				if pc.IsNotFound(err) {
+					return id, boosterStatusDeleted, nil
+				}
+
+				return nil, "", fmt.Errorf("error trying to read Booster status: %w", err)
+			}
+
+			if resp.Booster == nil {
+				return id, boosterStatusDeleted, nil
+			}
+
+			return resp, string(resp.Booster.Status), nil
+		},
+		Timeout:    timeout - time.Minute,
+		Delay:      waitDelay,
+		MinTimeout: waitMinTimeout,
+	}
+
+	_, err := deleteStateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for Booster to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func expandBoosterDimensions(def []interface{}) []*pc.DimensionInput {
+	dimensions := make([]*pc.DimensionInput, 0, len(def))
+
+	for _, rawDimension := range def {
+		dimensions = append(dimensions, &pc.DimensionInput{
+			ColumnName: rawDimension.(string),
+		})
+	}
+
+	return dimensions
+}
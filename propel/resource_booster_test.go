@@ -0,0 +1,74 @@
+package propel
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/propeldata/terraform-provider-propel/propel/internal/fakepropel"
+)
+
+func TestUnitPropelBoosterBasic(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+	server.SetDeletionTransitions(fakepropel.TypeBooster, "DELETING", "DELETING")
+
+	ctx := map[string]interface{}{
+		"unique_name":  acctest.RandString(10),
+		"filter_value": "foo",
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		// The Booster still exists if destroy did not wait for it to go through DELETING.
+		CheckDestroy: testUnitCheckDestroy(server, "propel_booster"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitPropelBoosterConfigBasic(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_booster.qux", "status", "LIVE"),
+					resource.TestCheckResourceAttr("propel_booster.qux", "dimensions.#", "1"),
+					resource.TestCheckResourceAttr("propel_booster.qux", "dimensions.0", "account_id"),
+					resource.TestCheckResourceAttrPair("propel_booster.qux", "metric", "propel_metric.baz", "id"),
+				),
+			},
+			{
+				ResourceName:      "propel_booster.qux",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitPropelBoosterFailed(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+	server.SetTransitions(fakepropel.TypeBooster, "OPTIMIZING", "FAILED")
+	server.SetErrorMessage(fakepropel.TypeBooster, "Not enough data to optimize")
+
+	ctx := map[string]interface{}{
+		"unique_name":  acctest.RandString(10),
+		"filter_value": "foo",
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_booster"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testUnitPropelBoosterConfigBasic(ctx),
+				ExpectError: regexp.MustCompile(`Booster failed to set up: Not enough data to optimize`),
+			},
+		},
+	})
+}
+
+func testUnitPropelBoosterConfigBasic(ctx map[string]interface{}) string {
+	return testUnitPropelMetricConfigBasic(ctx) + `
+
+	resource "propel_booster" "qux" {
+		metric = propel_metric.baz.id
+		dimensions = ["account_id"]
+	}`
+}
@@ -3,10 +3,10 @@ package propel
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -22,8 +22,9 @@ func resourceDataPool() *schema.Resource {
 		UpdateContext: resourceDataPoolUpdate,
 		DeleteContext: resourceDataPoolDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceDataPoolImport,
 		},
+		CustomizeDiff: resourceDataPoolCustomizeDiff,
 		SchemaVersion: 1,
 		Description:   "Provides a Propel Data Pool resource. This can be used to create and manage Propel Data Pools.",
 		Schema: map[string]*schema.Schema{
@@ -66,10 +67,10 @@ func resourceDataPool() *schema.Resource {
 				Description: "The name of the Data Pool's table.",
 			},
 			"column": {
-				Type:        schema.TypeList,
-				Required:    true,
-				ForceNew:    false,
-				Description: "The list of columns, their types and nullability.",
+				Type:             schema.TypeList,
+				Required:         true,
+				DiffSuppressFunc: resourceDataPoolColumnsDiffSuppress,
+				Description:      "The list of columns, their types and nullability.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -145,6 +146,20 @@ func expandPoolColumns(def []interface{}) []*pc.DataPoolColumnInput {
 	return columns
 }
 
+func flattenPoolColumns(columns []*pc.DataPoolColumnData) []interface{} {
+	flattened := make([]interface{}, 0, len(columns))
+
+	for _, column := range columns {
+		flattened = append(flattened, map[string]interface{}{
+			"name":     column.ColumnName,
+			"type":     string(column.Type),
+			"nullable": column.IsNullable,
+		})
+	}
+
+	return flattened
+}
+
 func resourceDataPoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(graphql.Client)
 
@@ -177,7 +192,7 @@ func resourceDataPoolCreate(ctx context.Context, d *schema.ResourceData, meta in
 
 	response, err := pc.CreateDataPool(ctx, c, input)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(response.CreateDataPoolV2.DataPool.Id)
@@ -186,7 +201,7 @@ func resourceDataPoolCreate(ctx context.Context, d *schema.ResourceData, meta in
 
 	err = waitForDataPoolLive(ctx, c, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	resourceDataPoolRead(ctx, d, meta)
@@ -201,7 +216,19 @@ func resourceDataPoolRead(ctx context.Context, d *schema.ResourceData, m interfa
 
 	response, err := pc.DataPool(ctx, c, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		if pc.IsNotFound(err) {
+			tflog.Warn(ctx, "Data Pool not found, removing from state", map[string]interface{}{"id": d.Id()})
+			d.SetId("")
+			return nil
+		}
+
+		return diagFromErr(err)
+	}
+
+	if response.DataPool == nil {
+		tflog.Warn(ctx, "Data Pool not found, removing from state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
 	}
 
 	d.SetId(response.DataPool.Id)
@@ -237,16 +264,38 @@ func resourceDataPoolRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
+	tenantId := ""
+	if response.DataPool.Tenant != nil {
+		tenantId = response.DataPool.Tenant.ColumnName
+	}
+
+	if err := d.Set("tenant_id", tenantId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	columns, err := pc.FetchDataPoolColumns(ctx, c, d.Id())
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	if err := d.Set("column", flattenPoolColumns(columns)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diags
 }
 
 func resourceDataPoolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(graphql.Client)
 
+	if d.HasChange("column") {
+		return diag.Errorf("The columns of Data Pool %q cannot be modified", d.Id())
+	}
+
 	if d.HasChanges("unique_name", "description") {
 		id := d.Id()
 		uniqueName := d.Get("unique_name").(string)
-		description := d.Get("descriptionunique_name").(string)
+		description := d.Get("description").(string)
 		input := &pc.ModifyDataPoolInput{
 			IdOrUniqueName: &pc.IdOrUniqueName{
 				Id: &id,
@@ -257,7 +306,7 @@ func resourceDataPoolUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 		_, err := pc.ModifyDataPool(ctx, c, input)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -271,19 +320,80 @@ func resourceDataPoolDelete(ctx context.Context, d *schema.ResourceData, m inter
 
 	_, err := pc.DeleteDataPool(ctx, c, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	timeout := d.Timeout(schema.TimeoutDelete)
 	err = waitForDataPoolDeletion(ctx, c, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId("")
 	return diags
 }
 
+// resourceDataPoolImport accepts either a Data Pool's ID or its unique name, which may be prefixed with "name:".
+func resourceDataPoolImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return importByIdOrUniqueName(ctx, d, meta, "Data Pool", func(ctx context.Context, c graphql.Client, id string) (string, error) {
+		response, err := pc.DataPool(ctx, c, id)
+		if err != nil || response.DataPool == nil {
+			return "", err
+		}
+
+		return response.DataPool.Id, nil
+	}, func(ctx context.Context, c graphql.Client, uniqueName string) (string, error) {
+		response, err := pc.DataPoolByName(ctx, c, uniqueName)
+		if err != nil || response.DataPool == nil {
+			return "", err
+		}
+
+		return response.DataPool.Id, nil
+	})
+}
+
+// resourceDataPoolColumnsDiffSuppress suppresses the diff of the Data Pool's columns as long as every configured
+// column is one of the Data Pool's columns, so that reordering them or adding columns outside of Terraform does
+// not replace the Data Pool.
+func resourceDataPoolColumnsDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	if d.Id() == "" {
+		return false
+	}
+
+	oldColumns, newColumns := d.GetChange("column")
+
+	return poolColumnsContained(newColumns.([]interface{}), oldColumns.([]interface{}))
+}
+
+// resourceDataPoolCustomizeDiff replaces the Data Pool when a configured column is not one of its columns, since a
+// Data Pool's columns cannot be modified.
+func resourceDataPoolCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("column") || !d.NewValueKnown("column") {
+		return nil
+	}
+
+	return forceNewNested(d, "column")
+}
+
+// poolColumnsContained returns whether every one of the columns is in pool, regardless of their order.
+func poolColumnsContained(columns, pool []interface{}) bool {
+	poolColumns := columnsByName(pool)
+
+	for _, rawColumn := range columns {
+		column, ok := rawColumn.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		poolColumn, ok := poolColumns[column["name"].(string)]
+		if !ok || !sameColumn(poolColumn, column) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func waitForDataPoolLive(ctx context.Context, client graphql.Client, id string, timeout time.Duration) error {
 	createStateConf := &resource.StateChangeConf{
 		Pending: []string{
@@ -296,33 +406,31 @@ func waitForDataPoolLive(ctx context.Context, client graphql.Client, id string,
 		Refresh: func() (interface{}, string, error) {
 			resp, err := pc.DataPool(ctx, client, id)
 			if err != nil {
-				return 0, "", fmt.Errorf("error trying to read Data Pool status: %s", err)
+				return 0, "", fmt.Errorf("error trying to read Data Pool status: %w", err)
 			}
 
 			return resp, string(resp.DataPool.Status), nil
 		},
 		Timeout:                   timeout - time.Minute,
-		Delay:                     10 * time.Second,
-		MinTimeout:                5 * time.Second,
+		Delay:                     waitDelay,
+		MinTimeout:                waitMinTimeout,
 		ContinuousTargetOccurence: 3,
 	}
 
 	_, err := createStateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf("error waiting for Data Pool to be LIVE: %s", err)
+		return fmt.Errorf("error waiting for Data Pool to be LIVE: %w", err)
 	}
 
 	return nil
 }
 
 func waitForDataPoolDeletion(ctx context.Context, client graphql.Client, id string, timeout time.Duration) error {
-	ticketInterval := 10 // 10s
-	timeoutSeconds := int(timeout.Seconds())
 	n := 0
 
-	ticker := time.NewTicker(time.Duration(ticketInterval) * time.Second)
+	ticker := time.NewTicker(deletionPollInterval)
 	for range ticker.C {
-		if n*ticketInterval > timeoutSeconds {
+		if time.Duration(n)*deletionPollInterval > timeout {
 			ticker.Stop()
 			break
 		}
@@ -331,11 +439,11 @@ func waitForDataPoolDeletion(ctx context.Context, client graphql.Client, id stri
 		if err != nil {
 			ticker.Stop()
 
-			if strings.Contains(err.Error(), "not found") {
+			if pc.IsNotFound(err) {
 				return nil
 			}
 
-			return fmt.Errorf("error trying to fetch Data Pool: %s", err)
+			return fmt.Errorf("error trying to fetch Data Pool: %w", err)
 		}
 
 		n++
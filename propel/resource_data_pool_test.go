@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
+	"github.com/propeldata/terraform-provider-propel/propel/internal/fakepropel"
 	pc "github.com/propeldata/terraform-provider-propel/propel_client"
 )
 
@@ -33,6 +36,191 @@ func TestAccPropelDataPoolBasic(t *testing.T) {
 	})
 }
 
+func TestUnitPropelDataPoolBasic(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+	// A page per column makes Read page through the Data Pool's columns.
+	server.SetMaxPageSize(1)
+
+	ctx := map[string]interface{}{}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_data_pool"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPropelDataPoolConfigBasic(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPropelDataPoolExists("propel_data_pool.bar"),
+					resource.TestCheckResourceAttr("propel_data_pool.bar", "status", "LIVE"),
+					resource.TestCheckResourceAttr("propel_data_pool.bar", "table", "CLUSTER_TEST_TABLE_1"),
+					resource.TestCheckResourceAttr("propel_data_pool.bar", "timestamp", "timestamp_tz"),
+					resource.TestCheckResourceAttrPair("propel_data_pool.bar", "data_source", "propel_data_source.foo", "id"),
+					resource.TestCheckResourceAttr("propel_data_pool.bar", "tenant_id", "account_id"),
+					resource.TestCheckResourceAttr("propel_data_pool.bar", "column.#", "2"),
+					resource.TestCheckResourceAttr("propel_data_pool.bar", "column.1.name", "account_id"),
+					resource.TestCheckResourceAttr("propel_data_pool.bar", "column.1.type", "STRING"),
+				),
+			},
+			{
+				ResourceName:      "propel_data_pool.bar",
+				ImportState:       true,
+				ImportStateId:     "name:terraform-test-3",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitPropelDataPoolSetupFailed(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+	server.SetTransitions(fakepropel.TypeDataPool, "PENDING", "SETUP_FAILED")
+
+	ctx := map[string]interface{}{}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_data_pool"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckPropelDataPoolConfigBasic(ctx),
+				ExpectError: regexp.MustCompile(`unexpected state 'SETUP_FAILED'`),
+			},
+		},
+	})
+}
+
+func TestUnitPropelDataPoolColumns(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_data_pool"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitPropelDataPoolConfigColumns(map[string]interface{}{
+					"unique_name":         "terraform-test-5",
+					"description":         "",
+					"account_id_nullable": false,
+					"reversed":            false,
+				}),
+				Check: testUnitStoreID("propel_data_pool.bar", &id),
+			},
+			{
+				// Listing the columns in another order does not change the Data Pool.
+				Config: testUnitPropelDataPoolConfigColumns(map[string]interface{}{
+					"unique_name":         "terraform-test-5",
+					"description":         "",
+					"account_id_nullable": false,
+					"reversed":            true,
+				}),
+				PlanOnly: true,
+			},
+			{
+				// Neither does a column added outside of Terraform.
+				PreConfig: func() {
+					server.AddDataPoolColumn(id, "value", pc.ColumnTypeInt64, true)
+				},
+				Config: testUnitPropelDataPoolConfigColumns(map[string]interface{}{
+					"unique_name":         "terraform-test-5",
+					"description":         "",
+					"account_id_nullable": false,
+					"reversed":            false,
+				}),
+				PlanOnly: true,
+			},
+			{
+				// Renaming the Data Pool and changing its description are done in place.
+				Config: testUnitPropelDataPoolConfigColumns(map[string]interface{}{
+					"unique_name":         "terraform-test-5-renamed",
+					"description":         "Renamed",
+					"account_id_nullable": false,
+					"reversed":            false,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_pool.bar", "unique_name", "terraform-test-5-renamed"),
+					resource.TestCheckResourceAttr("propel_data_pool.bar", "description", "Renamed"),
+					resource.TestCheckResourceAttrPtr("propel_data_pool.bar", "id", &id),
+				),
+			},
+			{
+				// Changing a column cannot be done in place, so it replaces the Data Pool.
+				Config: testUnitPropelDataPoolConfigColumns(map[string]interface{}{
+					"unique_name":         "terraform-test-5-renamed",
+					"description":         "Renamed",
+					"account_id_nullable": true,
+					"reversed":            false,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_pool.bar", "column.#", "2"),
+					resource.TestCheckResourceAttr("propel_data_pool.bar", "column.1.nullable", "true"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["propel_data_pool.bar"].Primary.ID == id {
+							return errors.New("expected the Data Pool to be replaced")
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testUnitPropelDataPoolConfigColumns(ctx map[string]interface{}) string {
+	columns := []string{
+		`column {
+			name = "timestamp_tz"
+			type = "TIMESTAMP"
+			nullable = false
+		}`,
+		fmt.Sprintf(`column {
+			name = "account_id"
+			type = "STRING"
+			nullable = %t
+		}`, ctx["account_id_nullable"]),
+	}
+
+	if ctx["reversed"].(bool) {
+		columns[0], columns[1] = columns[1], columns[0]
+	}
+
+	ctx["columns"] = strings.Join(columns, "\n\t\t")
+
+	return Nprintf(`
+	resource "propel_data_source" "foo" {
+		unique_name = "terraform-test-5"
+		type = "Http"
+
+		table {
+			name = "events"
+
+			column {
+				name = "timestamp_tz"
+				type = "TIMESTAMP"
+				nullable = false
+			}
+
+			column {
+				name = "account_id"
+				type = "STRING"
+				nullable = true
+			}
+		}
+	}
+
+	resource "propel_data_pool" "bar" {
+		unique_name = "%{unique_name}"
+		description = "%{description}"
+		table = propel_data_source.foo.table[0].name
+		timestamp = "timestamp_tz"
+		data_source = propel_data_source.foo.id
+
+		%{columns}
+	}`, ctx)
+}
+
 func testAccCheckPropelDataPoolConfigBasic(ctx map[string]interface{}) string {
 	return Nprintf(`
 	resource "propel_data_source" "foo" {
@@ -42,7 +230,7 @@ func testAccCheckPropelDataPoolConfigBasic(ctx map[string]interface{}) string {
 		http_connection_settings {
 			basic_auth {
 				username = "foo"
-				password = "bar"
+				password = "`+testAccSecret("bar")+`"
 			}
 		}
 
@@ -94,7 +282,7 @@ func testAccCheckPropelDataPoolDestroy(s *terraform.State) error {
 		dataPoolID := rs.Primary.ID
 
 		_, err := pc.DeleteDataPool(context.Background(), c, dataPoolID)
-		if err != nil {
+		if err != nil && !pc.IsNotFound(err) {
 			return err
 		}
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -23,8 +24,9 @@ func resourceDataSource() *schema.Resource {
 		UpdateContext: resourceDataSourceUpdate,
 		DeleteContext: resourceDataSourceDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceDataSourceImport,
 		},
+		CustomizeDiff: resourceDataSourceCustomizeDiff,
 		SchemaVersion: 1,
 		Description:   "Provides a Propel Data Source resource. This can be used to create and manage Propel Data Sources.",
 		Schema: map[string]*schema.Schema{
@@ -47,8 +49,10 @@ func resourceDataSource() *schema.Resource {
 					"Snowflake",
 					"S3",
 					"Http",
+					"BIGQUERY",
+					"Redshift",
 				}, true),
-				Description: "The Data Source's type. Depending on this, you will need to specify one of `http_connection_settings`, `s3_connection_settings`, or `snowflake_connection_settings`.",
+				Description: "The Data Source's type. Depending on this, you will need to specify one of `http_connection_settings`, `s3_connection_settings`, `snowflake_connection_settings`, `bigquery_connection_settings`, or `redshift_connection_settings`.",
 			},
 			"status": {
 				Type:        schema.TypeString,
@@ -88,7 +92,7 @@ func resourceDataSource() *schema.Resource {
 			"snowflake_connection_settings": {
 				Type:          schema.TypeList,
 				Optional:      true,
-				ConflictsWith: []string{"http_connection_settings", "s3_connection_settings"},
+				ConflictsWith: []string{"http_connection_settings", "s3_connection_settings", "bigquery_connection_settings", "redshift_connection_settings"},
 				MaxItems:      1,
 				Description:   "Snowflake connection settings. Specify these for Snowflake Data Sources.",
 				Elem: &schema.Resource{
@@ -135,7 +139,7 @@ func resourceDataSource() *schema.Resource {
 			"http_connection_settings": {
 				Type:          schema.TypeList,
 				Optional:      true,
-				ConflictsWith: []string{"snowflake_connection_settings", "s3_connection_settings"},
+				ConflictsWith: []string{"snowflake_connection_settings", "s3_connection_settings", "bigquery_connection_settings", "redshift_connection_settings"},
 				MaxItems:      1,
 				Elem: &schema.Resource{
 					Description: "HTTP connection settings. Specify these for HTTP Data Sources.",
@@ -155,6 +159,7 @@ func resourceDataSource() *schema.Resource {
 									"password": {
 										Type:        schema.TypeString,
 										Required:    true,
+										Sensitive:   true,
 										Description: "The password for HTTP Basic authentication that must be included in the Authorization header when uploading new data.",
 									},
 								},
@@ -166,7 +171,7 @@ func resourceDataSource() *schema.Resource {
 			"s3_connection_settings": {
 				Type:          schema.TypeList,
 				Optional:      true,
-				ConflictsWith: []string{"snowflake_connection_settings", "http_connection_settings"},
+				ConflictsWith: []string{"snowflake_connection_settings", "http_connection_settings", "bigquery_connection_settings", "redshift_connection_settings"},
 				MaxItems:      1,
 				Elem: &schema.Resource{
 					Description: "The connection settings for an S3 Data Source. These include the S3 bucket name, the AWS access key ID, the AWS secret access key, and the tables (along with their paths).",
@@ -184,15 +189,94 @@ func resourceDataSource() *schema.Resource {
 						"aws_secret_access_key": {
 							Type:        schema.TypeString,
 							Required:    true,
+							Sensitive:   true,
 							Description: "The AWS secret access key for an IAM user with sufficient access to the S3 bucket.",
 						},
 					},
 				},
 			},
+			"bigquery_connection_settings": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"snowflake_connection_settings", "http_connection_settings", "s3_connection_settings", "redshift_connection_settings"},
+				MaxItems:      1,
+				Description:   "BigQuery connection settings. Specify these for BigQuery Data Sources.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"credentials_json": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The contents of your Service Account Key JSON file.",
+						},
+						"dataset_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the dataset that contains the tables you are going to use with Propel.",
+						},
+						"project_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The GCP project ID for the project containing the target BigQuery dataset.",
+						},
+					},
+				},
+			},
+			"redshift_connection_settings": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"snowflake_connection_settings", "http_connection_settings", "s3_connection_settings", "bigquery_connection_settings"},
+				MaxItems:      1,
+				Description:   "Redshift connection settings. Specify these for Redshift Data Sources. Propel can only connect once the AWS IAM role trusting `propel_role_arn` exists, so the provider does not wait for Redshift Data Sources to be connected.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The Redshift host.",
+						},
+						"port": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The Redshift port.",
+						},
+						"database": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The Redshift database name.",
+						},
+						"schema": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The Redshift schema.",
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The Redshift username with sufficient credentials.",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The Redshift password.",
+						},
+						"aws_account_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The 12-digit ID of the AWS account that Propel will give permission to for syncing from Redshift to Propel's S3 bucket.",
+						},
+					},
+				},
+			},
+			"propel_role_arn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ARN of the role generated by Propel for Redshift Data Sources. Grant it permission to unload files from your Redshift cluster to Propel's S3 bucket.",
+			},
 			"table": {
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
 				Elem: &schema.Resource{
 					Description: "Specify an HTTP or S3 Data Source's tables with this. You do not need to use this for Snowflake Data Sources, since Snowflake Data Sources' tables are automatically introspected.",
 					Schema: map[string]*schema.Schema{
@@ -209,8 +293,7 @@ func resourceDataSource() *schema.Resource {
 						"column": {
 							Type:        schema.TypeList,
 							Required:    true,
-							ForceNew:    true,
-							Description: "Specify a table's columns.",
+							Description: "Specify a table's columns. HTTP and S3 Data Sources can get new tables and new nullable columns in place. Any other change to the tables or their columns replaces the Data Source.",
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"name": {
@@ -250,21 +333,32 @@ func resourceDataSource() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 	}
 }
 
+// normalizeDataSourceType returns a Data Source type in upper case, since the Propel GraphQL API returns some types,
+// such as "Http", in mixed case.
+// TODO(mroberts): The Propel GraphQL API should eventually return this uppercase.
+func normalizeDataSourceType(dataSourceType string) string {
+	return strings.ToUpper(dataSourceType)
+}
+
 func resourceDataSourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// TODO(mroberts): The Propel GraphQL API should eventually return this uppercase.
 	dataSourceType := d.Get("type").(string)
-	switch strings.ToUpper(dataSourceType) {
+	switch normalizeDataSourceType(dataSourceType) {
 	case "SNOWFLAKE":
 		return resourceSnowflakeDataSourceCreate(ctx, d, meta)
 	case "HTTP":
 		return resourceHttpDataSourceCreate(ctx, d, meta)
 	case "S3":
 		return resourceS3DataSourceCreate(ctx, d, meta)
+	case "BIGQUERY":
+		return resourceBigQueryDataSourceCreate(ctx, d, meta)
+	case "REDSHIFT":
+		return resourceRedshiftDataSourceCreate(ctx, d, meta)
 	default:
 		return diag.Errorf("Unsupported Data Source type \"%v\"", dataSourceType)
 	}
@@ -296,7 +390,7 @@ func resourceSnowflakeDataSourceCreate(ctx context.Context, d *schema.ResourceDa
 
 	response, err := pc.CreateSnowflakeDataSource(ctx, c, input)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	switch r := (*response.GetCreateSnowflakeDataSource()).(type) {
@@ -307,15 +401,12 @@ func resourceSnowflakeDataSourceCreate(ctx context.Context, d *schema.ResourceDa
 
 		err = waitForDataSourceConnected(ctx, c, d.Id(), timeout)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
 		return resourceDataSourceRead(ctx, d, meta)
 	case *pc.CreateSnowflakeDataSourceCreateSnowflakeDataSourceFailureResponse:
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to create Data Source",
-		})
+		diags = append(diags, diagFromFailure("Failed to create Data Source", r.Error.Code, r.Error.Message)...)
 	}
 
 	return diags
@@ -325,12 +416,8 @@ func resourceHttpDataSourceCreate(ctx context.Context, d *schema.ResourceData, m
 	c := meta.(graphql.Client)
 
 	var basicAuth *pc.HttpBasicAuthInput
-	if d.Get("http_connection_settings") != nil && len(d.Get("http_connection_settings").([]interface{})) > 0 {
-		cs := d.Get("http_connection_settings").([]interface{})[0].(map[string]interface{})
-
-		if def, ok := cs["basic_auth"]; ok {
-			basicAuth = expandBasicAuth(def.([]interface{}))
-		}
+	if def, ok := d.Get("http_connection_settings.0.basic_auth").([]interface{}); ok && len(def) > 0 {
+		basicAuth = expandBasicAuth(def)
 	}
 
 	tables := make([]*pc.HttpDataSourceTableInput, 0)
@@ -351,7 +438,7 @@ func resourceHttpDataSourceCreate(ctx context.Context, d *schema.ResourceData, m
 
 	response, err := pc.CreateHttpDataSource(ctx, c, input)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	r := response.CreateHttpDataSource
@@ -361,7 +448,7 @@ func resourceHttpDataSourceCreate(ctx context.Context, d *schema.ResourceData, m
 
 	err = waitForDataSourceConnected(ctx, c, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return resourceDataSourceRead(ctx, d, meta)
@@ -392,7 +479,7 @@ func resourceS3DataSourceCreate(ctx context.Context, d *schema.ResourceData, met
 
 	response, err := pc.CreateS3DataSource(ctx, c, input)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	r := response.CreateS3DataSource
@@ -402,18 +489,99 @@ func resourceS3DataSourceCreate(ctx context.Context, d *schema.ResourceData, met
 
 	err = waitForDataSourceConnected(ctx, c, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return resourceDataSourceRead(ctx, d, meta)
 }
 
+func resourceBigQueryDataSourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	connectionSettings := d.Get("bigquery_connection_settings").([]interface{})[0].(map[string]interface{})
+
+	uniqueName := d.Get("unique_name").(string)
+	description := d.Get("description").(string)
+	input := &pc.CreateBigQueryDataSourceInput{
+		UniqueName:  &uniqueName,
+		Description: &description,
+		ConnectionSettings: &pc.BigQueryConnectionSettingsInput{
+			CredentialsJson: connectionSettings["credentials_json"].(string),
+			DataSetId:       connectionSettings["dataset_id"].(string),
+			ProjectId:       connectionSettings["project_id"].(string),
+		},
+	}
+
+	response, err := pc.CreateBigQueryDataSource(ctx, c, input)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	r := response.CreateBigQueryDataSource
+	d.SetId(r.DataSource.Id)
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	err = waitForDataSourceConnected(ctx, c, d.Id(), timeout)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	return resourceDataSourceRead(ctx, d, meta)
+}
+
+func resourceRedshiftDataSourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	connectionSettings := d.Get("redshift_connection_settings").([]interface{})[0].(map[string]interface{})
+
+	uniqueName := d.Get("unique_name").(string)
+	description := d.Get("description").(string)
+	input := &pc.CreateRedshiftDataSourceInput{
+		UniqueName:  &uniqueName,
+		Description: &description,
+		ConnectionSettings: &pc.RedshiftConnectionSettingsInput{
+			Host:         connectionSettings["host"].(string),
+			Port:         connectionSettings["port"].(int),
+			Database:     connectionSettings["database"].(string),
+			Schema:       connectionSettings["schema"].(string),
+			Username:     connectionSettings["username"].(string),
+			Password:     connectionSettings["password"].(string),
+			AwsAccountId: connectionSettings["aws_account_id"].(string),
+		},
+	}
+
+	response, err := pc.CreateRedshiftDataSource(ctx, c, input)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	r := response.CreateRedshiftDataSource
+	d.SetId(r.DataSource.Id)
+
+	// We don't wait for the Data Source to be connected here: Propel cannot connect to Redshift until the role
+	// trusting `propel_role_arn` has been created, which is usually done in the same plan.
+	return resourceDataSourceRead(ctx, d, meta)
+}
+
 func resourceDataSourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(graphql.Client)
 
 	response, err := pc.DataSource(ctx, c, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		if pc.IsNotFound(err) {
+			tflog.Warn(ctx, "Data Source not found, removing from state", map[string]interface{}{"id": d.Id()})
+			d.SetId("")
+			return nil
+		}
+
+		return diagFromErr(err)
+	}
+
+	if response.DataSource == nil {
+		tflog.Warn(ctx, "Data Source not found, removing from state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
 	}
 
 	d.SetId(response.DataSource.Id)
@@ -457,31 +625,35 @@ func resourceDataSourceRead(ctx context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(err)
 	}
 
-	// TODO(mroberts): The Propel GraphQL API should eventually return this uppercase.
 	dataSourceType := string(response.DataSource.Type)
-	switch strings.ToUpper(dataSourceType) {
+	switch normalizeDataSourceType(dataSourceType) {
 	case "SNOWFLAKE":
 		return handleSnowflakeConnectionSettings(response, d)
 	case "HTTP":
-		if diags := handleHttpTables(response, d); diags != nil {
+		if diags := handleHttpTables(ctx, c, response, d); diags != nil {
 			return diags
 		}
 		return handleHttpConnectionSettings(response, d)
 	case "S3":
-		if diags := handleS3Tables(response, d); diags != nil {
+		if diags := handleS3Tables(ctx, c, response, d); diags != nil {
 			return diags
 		}
 		return handleS3ConnectionSettings(response, d)
+	case "BIGQUERY":
+		return handleBigQueryConnectionSettings(response, d)
+	case "REDSHIFT":
+		return handleRedshiftConnectionSettings(response, d)
 	default:
 		return diag.Errorf("Unsupported Data Source type \"%v\"", dataSourceType)
 	}
 }
 
 func handleSnowflakeConnectionSettings(response *pc.DataSourceResponse, d *schema.ResourceData) diag.Diagnostics {
-	cs := d.Get("snowflake_connection_settings").([]interface{})[0].(map[string]interface{})
+	settings := map[string]interface{}{}
 
-	settings := map[string]interface{}{
-		"password": cs["password"],
+	// The password is never returned by the API, so we keep the one in the state.
+	if def, ok := d.Get("snowflake_connection_settings").([]interface{}); ok && len(def) > 0 && def[0] != nil {
+		settings["password"] = def[0].(map[string]interface{})["password"]
 	}
 
 	switch s := response.DataSource.GetConnectionSettings().(type) {
@@ -503,102 +675,110 @@ func handleSnowflakeConnectionSettings(response *pc.DataSourceResponse, d *schem
 	return nil
 }
 
-func handleHttpTables(response *pc.DataSourceResponse, d *schema.ResourceData) diag.Diagnostics {
+func handleHttpTables(ctx context.Context, c graphql.Client, response *pc.DataSourceResponse, d *schema.ResourceData) diag.Diagnostics {
 	// FIXME(mroberts): We need to handle the case where tables is not yet populated.
 	if response.DataSource.Tables == nil {
 		return nil
 	}
 
-	tables := make([]interface{}, 0, len(response.DataSource.Tables.Nodes))
-
-	// FIXME(mroberts): This is only going to work for the first page of results.
-	for _, table := range response.DataSource.Tables.Nodes {
-		columns := make([]interface{}, 0, len(table.Columns.Nodes))
-
-		// FIXME(mroberts): This is only going to work for the first page of results.
-		for _, column := range table.Columns.Nodes {
-			columns = append(columns, map[string]interface{}{
-				"name":     column.Name,
-				"type":     column.Type,
-				"nullable": column.IsNullable,
-			})
-		}
-
-		tables = append(tables, map[string]interface{}{
-			"name":   table.Name,
-			"column": columns,
-		})
+	tables, err := pc.FetchDataSourceTables(ctx, c, response.DataSource.Id)
+	if err != nil {
+		return diagFromErr(err)
 	}
 
-	if err := d.Set("table", (interface{})(tables)); err != nil {
+	if err := d.Set("table", flattenDataSourceTables(tables, nil)); err != nil {
 		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func handleS3Tables(response *pc.DataSourceResponse, d *schema.ResourceData) diag.Diagnostics {
+func handleS3Tables(ctx context.Context, c graphql.Client, response *pc.DataSourceResponse, d *schema.ResourceData) diag.Diagnostics {
 	// FIXME(mroberts): We need to handle the case where tables is not yet populated.
 	if response.DataSource.Tables == nil {
 		return nil
 	}
 
-	tables := make([]interface{}, 0, len(response.DataSource.Tables.Nodes))
+	// The tables' paths are only part of the S3 connection settings.
+	paths := make(map[string]string)
+	if s, ok := response.DataSource.GetConnectionSettings().(*pc.DataSourceDataConnectionSettingsS3ConnectionSettings); ok {
+		for _, table := range s.Tables {
+			if table.Path != nil {
+				paths[table.Name] = *table.Path
+			}
+		}
+	}
+
+	tables, err := pc.FetchDataSourceTables(ctx, c, response.DataSource.Id)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	if err := d.Set("table", flattenDataSourceTables(tables, paths)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func flattenDataSourceTables(tables []*pc.DataSourceTable, paths map[string]string) []interface{} {
+	flattened := make([]interface{}, 0, len(tables))
 
-	// FIXME(mroberts): This is only going to work for the first page of results.
-	for _, table := range response.DataSource.Tables.Nodes {
-		columns := make([]interface{}, 0, len(table.Columns.Nodes))
+	for _, table := range tables {
+		columns := make([]interface{}, 0, len(table.Columns))
 
-		// FIXME(mroberts): This is only going to work for the first page of results.
-		for _, column := range table.Columns.Nodes {
+		for _, column := range table.Columns {
 			columns = append(columns, map[string]interface{}{
-				"name": column.Name,
-				// FIXME(mroberts): What about `path`?
+				"name":     column.Name,
 				"type":     column.Type,
-				"nullable": column.IsNullable,
+				"nullable": column.IsNullable != nil && *column.IsNullable,
 			})
 		}
 
-		tables = append(tables, map[string]interface{}{
+		flattened = append(flattened, map[string]interface{}{
 			"name":   table.Name,
+			"path":   paths[table.Name],
 			"column": columns,
 		})
 	}
 
-	if err := d.Set("table", (interface{})(tables)); err != nil {
-		return diag.FromErr(err)
-	}
-
-	return nil
+	return flattened
 }
 
 func handleHttpConnectionSettings(response *pc.DataSourceResponse, d *schema.ResourceData) diag.Diagnostics {
-	if d.Get("http_connection_settings") == nil || len(d.Get("http_connection_settings").([]interface{})) == 0 {
-		return nil
-	}
-
-	cs := d.Get("http_connection_settings").([]interface{})[0].(map[string]interface{})
+	basicAuth := make([]interface{}, 0, 1)
 
 	switch s := response.DataSource.GetConnectionSettings().(type) {
 	case *pc.DataSourceDataConnectionSettingsHttpConnectionSettings:
-		if s.BasicAuth == nil {
-			cs["basic_auth"] = nil
-		} else if cs["basic_auth"] != nil && len(cs["basic_auth"].([]interface{})) > 0 {
-			basicAuth := cs["basic_auth"].([]interface{})[0].(map[string]interface{})
-			basicAuth["username"] = s.BasicAuth.Username
+		if s.BasicAuth != nil {
+			basicAuth = append(basicAuth, map[string]interface{}{
+				"username": s.BasicAuth.Username,
+				"password": s.BasicAuth.Password,
+			})
 		}
 	default:
 		return diag.Errorf("Missing HttpConnectionSettings")
 	}
 
+	// An empty block is left out, so that Data Sources without HTTP Basic authentication don't need one.
+	if len(basicAuth) == 0 && len(d.Get("http_connection_settings").([]interface{})) == 0 {
+		return nil
+	}
+
+	settings := []map[string]interface{}{{"basic_auth": basicAuth}}
+	if err := d.Set("http_connection_settings", settings); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
 func handleS3ConnectionSettings(response *pc.DataSourceResponse, d *schema.ResourceData) diag.Diagnostics {
-	cs := d.Get("s3_connection_settings").([]interface{})[0].(map[string]interface{})
+	settings := map[string]interface{}{}
 
-	settings := map[string]interface{}{
-		"aws_secret_access_key": cs["awsSecretAccessKey"],
+	// The secret access key is never returned by the API, so we keep the one in the state.
+	if def, ok := d.Get("s3_connection_settings").([]interface{}); ok && len(def) > 0 && def[0] != nil {
+		settings["aws_secret_access_key"] = def[0].(map[string]interface{})["aws_secret_access_key"]
 	}
 
 	switch s := response.DataSource.GetConnectionSettings().(type) {
@@ -609,17 +789,98 @@ func handleS3ConnectionSettings(response *pc.DataSourceResponse, d *schema.Resou
 		return diag.Errorf("Missing S3ConnectionSettings")
 	}
 
+	if err := d.Set("s3_connection_settings", []map[string]interface{}{settings}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func handleBigQueryConnectionSettings(response *pc.DataSourceResponse, d *schema.ResourceData) diag.Diagnostics {
+	settings := map[string]interface{}{}
+
+	// The credentials are never returned by the API, so we keep the ones in the state.
+	if def, ok := d.Get("bigquery_connection_settings").([]interface{}); ok && len(def) > 0 && def[0] != nil {
+		settings["credentials_json"] = def[0].(map[string]interface{})["credentials_json"]
+	}
+
+	switch s := response.DataSource.GetConnectionSettings().(type) {
+	case *pc.DataSourceDataConnectionSettingsBigQueryConnectionSettings:
+		settings["dataset_id"] = s.GetDataSetId()
+		settings["project_id"] = s.GetProjectId()
+	default:
+		return diag.Errorf("Missing BigQueryConnectionSettings")
+	}
+
+	if err := d.Set("bigquery_connection_settings", []map[string]interface{}{settings}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func handleRedshiftConnectionSettings(response *pc.DataSourceResponse, d *schema.ResourceData) diag.Diagnostics {
+	settings := map[string]interface{}{}
+
+	// The password is never returned by the API, so we keep the one in the state.
+	if def, ok := d.Get("redshift_connection_settings").([]interface{}); ok && len(def) > 0 && def[0] != nil {
+		settings["password"] = def[0].(map[string]interface{})["password"]
+	}
+
+	switch s := response.DataSource.GetConnectionSettings().(type) {
+	case *pc.DataSourceDataConnectionSettingsRedshiftConnectionSettings:
+		settings["host"] = s.GetHost()
+		settings["port"] = s.GetPort()
+		settings["database"] = s.GetDatabase()
+		settings["schema"] = s.GetSchema()
+		settings["username"] = s.GetUsername()
+		settings["aws_account_id"] = s.GetAwsAccountId()
+
+		propelRoleArn := ""
+		if s.GetPropelRoleArn() != nil {
+			propelRoleArn = *s.GetPropelRoleArn()
+		}
+
+		if err := d.Set("propel_role_arn", propelRoleArn); err != nil {
+			return diag.FromErr(err)
+		}
+	default:
+		return diag.Errorf("Missing RedshiftConnectionSettings")
+	}
+
+	if err := d.Set("redshift_connection_settings", []map[string]interface{}{settings}); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
 func resourceDataSourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	dataSourceType := d.Get("type").(string)
+	switch normalizeDataSourceType(dataSourceType) {
+	case "SNOWFLAKE":
+		return resourceSnowflakeDataSourceUpdate(ctx, d, m)
+	case "HTTP":
+		return resourceHttpDataSourceUpdate(ctx, d, m)
+	case "S3":
+		return resourceS3DataSourceUpdate(ctx, d, m)
+	case "BIGQUERY":
+		return resourceBigQueryDataSourceUpdate(ctx, d, m)
+	case "REDSHIFT":
+		return resourceRedshiftDataSourceUpdate(ctx, d, m)
+	default:
+		return diag.Errorf("Unsupported Data Source type \"%v\"", dataSourceType)
+	}
+}
+
+func resourceSnowflakeDataSourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(graphql.Client)
 
-	if d.HasChanges("unique_name", "description") {
+	if d.HasChanges("unique_name", "description", "snowflake_connection_settings") {
 		id := d.Id()
 		uniqueName := d.Get("unique_name").(string)
 		description := d.Get("description").(string)
-		modifyDataSource := &pc.ModifySnowflakeDataSourceInput{
+		input := &pc.ModifySnowflakeDataSourceInput{
 			IdOrUniqueName: &pc.IdOrUniqueName{
 				Id: &id,
 			},
@@ -627,9 +888,287 @@ func resourceDataSourceUpdate(ctx context.Context, d *schema.ResourceData, m int
 			Description: &description,
 		}
 
-		_, err := pc.ModifySnowflakeDataSource(ctx, c, modifyDataSource)
+		if d.HasChange("snowflake_connection_settings") {
+			cs := d.Get("snowflake_connection_settings").([]interface{})[0].(map[string]interface{})
+			settings := &pc.PartialSnowflakeConnectionSettingsInput{}
+
+			if d.HasChange("snowflake_connection_settings.0.account") {
+				account := cs["account"].(string)
+				settings.Account = &account
+			}
+
+			if d.HasChange("snowflake_connection_settings.0.database") {
+				database := cs["database"].(string)
+				settings.Database = &database
+			}
+
+			if d.HasChange("snowflake_connection_settings.0.warehouse") {
+				warehouse := cs["warehouse"].(string)
+				settings.Warehouse = &warehouse
+			}
+
+			if d.HasChange("snowflake_connection_settings.0.schema") {
+				snowflakeSchema := cs["schema"].(string)
+				settings.Schema = &snowflakeSchema
+			}
+
+			if d.HasChange("snowflake_connection_settings.0.role") {
+				role := cs["role"].(string)
+				settings.Role = &role
+			}
+
+			if d.HasChange("snowflake_connection_settings.0.username") {
+				username := cs["username"].(string)
+				settings.Username = &username
+			}
+
+			if d.HasChange("snowflake_connection_settings.0.password") {
+				password := cs["password"].(string)
+				settings.Password = &password
+			}
+
+			input.ConnectionSettings = settings
+		}
+
+		_, err := pc.ModifySnowflakeDataSource(ctx, c, input)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
+		}
+
+		// Propel only uses the new connection settings once the Data Source reconnects.
+		if d.HasChange("snowflake_connection_settings") {
+			if diags := reconnectDataSource(ctx, c, d); diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	return resourceDataSourceRead(ctx, d, m)
+}
+
+func resourceHttpDataSourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(graphql.Client)
+
+	if d.HasChanges("unique_name", "description", "http_connection_settings", "table") {
+		id := d.Id()
+		uniqueName := d.Get("unique_name").(string)
+		description := d.Get("description").(string)
+		input := &pc.ModifyHttpDataSourceInput{
+			IdOrUniqueName: &pc.IdOrUniqueName{
+				Id: &id,
+			},
+			UniqueName:  &uniqueName,
+			Description: &description,
+		}
+
+		if d.HasChanges("http_connection_settings", "table") {
+			settings := &pc.PartialHttpConnectionSettingsInput{}
+
+			if d.HasChange("http_connection_settings.0.basic_auth") {
+				basicAuthEnabled := false
+
+				if def, ok := d.Get("http_connection_settings.0.basic_auth").([]interface{}); ok && len(def) > 0 {
+					settings.BasicAuth = expandBasicAuth(def)
+					basicAuthEnabled = true
+				}
+
+				settings.BasicAuthEnabled = &basicAuthEnabled
+			}
+
+			if d.HasChange("table") {
+				settings.Tables = expandHttpTables(d.Get("table").([]interface{}))
+			}
+
+			input.ConnectionSettings = settings
+		}
+
+		_, err := pc.ModifyHttpDataSource(ctx, c, input)
+		if err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	return resourceDataSourceRead(ctx, d, m)
+}
+
+func resourceS3DataSourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(graphql.Client)
+
+	if d.HasChanges("unique_name", "description", "s3_connection_settings", "table") {
+		id := d.Id()
+		uniqueName := d.Get("unique_name").(string)
+		description := d.Get("description").(string)
+		input := &pc.ModifyS3DataSourceInput{
+			IdOrUniqueName: &pc.IdOrUniqueName{
+				Id: &id,
+			},
+			UniqueName:  &uniqueName,
+			Description: &description,
+		}
+
+		if d.HasChanges("s3_connection_settings", "table") {
+			cs := d.Get("s3_connection_settings").([]interface{})[0].(map[string]interface{})
+			settings := &pc.PartialS3ConnectionSettingsInput{}
+
+			if d.HasChange("s3_connection_settings.0.bucket") {
+				bucket := cs["bucket"].(string)
+				settings.Bucket = &bucket
+			}
+
+			if d.HasChange("s3_connection_settings.0.aws_access_key_id") {
+				awsAccessKeyId := cs["aws_access_key_id"].(string)
+				settings.AwsAccessKeyId = &awsAccessKeyId
+			}
+
+			if d.HasChange("s3_connection_settings.0.aws_secret_access_key") {
+				awsSecretAccessKey := cs["aws_secret_access_key"].(string)
+				settings.AwsSecretAccessKey = &awsSecretAccessKey
+			}
+
+			if d.HasChange("table") {
+				settings.Tables = expandS3Tables(d.Get("table").([]interface{}))
+			}
+
+			input.ConnectionSettings = settings
+		}
+
+		_, err := pc.ModifyS3DataSource(ctx, c, input)
+		if err != nil {
+			return diagFromErr(err)
+		}
+
+		// Propel only uses the new bucket and credentials once the Data Source reconnects.
+		if d.HasChange("s3_connection_settings") {
+			if diags := reconnectDataSource(ctx, c, d); diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	return resourceDataSourceRead(ctx, d, m)
+}
+
+func resourceBigQueryDataSourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(graphql.Client)
+
+	if d.HasChanges("unique_name", "description", "bigquery_connection_settings") {
+		id := d.Id()
+		uniqueName := d.Get("unique_name").(string)
+		description := d.Get("description").(string)
+		input := &pc.ModifyBigQueryDataSourceInput{
+			IdOrUniqueName: &pc.IdOrUniqueName{
+				Id: &id,
+			},
+			UniqueName:  &uniqueName,
+			Description: &description,
+		}
+
+		if d.HasChange("bigquery_connection_settings") {
+			cs := d.Get("bigquery_connection_settings").([]interface{})[0].(map[string]interface{})
+			settings := &pc.PartialBigQueryConnectionSettingsInput{}
+
+			if d.HasChange("bigquery_connection_settings.0.credentials_json") {
+				credentialsJson := cs["credentials_json"].(string)
+				settings.CredentialsJson = &credentialsJson
+			}
+
+			if d.HasChange("bigquery_connection_settings.0.dataset_id") {
+				dataSetId := cs["dataset_id"].(string)
+				settings.DataSetId = &dataSetId
+			}
+
+			if d.HasChange("bigquery_connection_settings.0.project_id") {
+				projectId := cs["project_id"].(string)
+				settings.ProjectId = &projectId
+			}
+
+			input.ConnectionSettings = settings
+		}
+
+		_, err := pc.ModifyBigQueryDataSource(ctx, c, input)
+		if err != nil {
+			return diagFromErr(err)
+		}
+
+		// Propel only uses the new credentials, dataset and project once the Data Source reconnects.
+		if d.HasChange("bigquery_connection_settings") {
+			if diags := reconnectDataSource(ctx, c, d); diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	return resourceDataSourceRead(ctx, d, m)
+}
+
+func resourceRedshiftDataSourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(graphql.Client)
+
+	if d.HasChanges("unique_name", "description", "redshift_connection_settings") {
+		id := d.Id()
+		uniqueName := d.Get("unique_name").(string)
+		description := d.Get("description").(string)
+		input := &pc.ModifyRedshiftDataSourceInput{
+			IdOrUniqueName: &pc.IdOrUniqueName{
+				Id: &id,
+			},
+			UniqueName:  &uniqueName,
+			Description: &description,
+		}
+
+		if d.HasChange("redshift_connection_settings") {
+			cs := d.Get("redshift_connection_settings").([]interface{})[0].(map[string]interface{})
+			settings := &pc.PartialRedshiftConnectionSettingsInput{}
+
+			if d.HasChange("redshift_connection_settings.0.host") {
+				host := cs["host"].(string)
+				settings.Host = &host
+			}
+
+			if d.HasChange("redshift_connection_settings.0.port") {
+				port := cs["port"].(int)
+				settings.Port = &port
+			}
+
+			if d.HasChange("redshift_connection_settings.0.database") {
+				database := cs["database"].(string)
+				settings.Database = &database
+			}
+
+			if d.HasChange("redshift_connection_settings.0.schema") {
+				redshiftSchema := cs["schema"].(string)
+				settings.Schema = &redshiftSchema
+			}
+
+			if d.HasChange("redshift_connection_settings.0.username") {
+				username := cs["username"].(string)
+				settings.Username = &username
+			}
+
+			if d.HasChange("redshift_connection_settings.0.password") {
+				password := cs["password"].(string)
+				settings.Password = &password
+			}
+
+			if d.HasChange("redshift_connection_settings.0.aws_account_id") {
+				awsAccountId := cs["aws_account_id"].(string)
+				settings.AwsAccountId = &awsAccountId
+			}
+
+			input.ConnectionSettings = settings
+		}
+
+		_, err := pc.ModifyRedshiftDataSource(ctx, c, input)
+		if err != nil {
+			return diagFromErr(err)
+		}
+
+		// Propel only uses the new connection settings once the Data Source reconnects. As when creating it, we don't
+		// wait for it to be connected, since that may depend on changes to the role trusting `propel_role_arn`.
+		if d.HasChange("redshift_connection_settings") {
+			if _, err := pc.ReconnectDataSource(ctx, c, &pc.IdOrUniqueName{Id: &id}); err != nil {
+				return diagFromErr(err)
+			}
 		}
 	}
 
@@ -644,7 +1183,7 @@ func resourceDataSourceDelete(ctx context.Context, d *schema.ResourceData, m int
 
 	_, err := pc.DeleteDataSource(ctx, c, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// d.SetId("") is automatically called assuming delete returns no errors, but
@@ -654,6 +1193,100 @@ func resourceDataSourceDelete(ctx context.Context, d *schema.ResourceData, m int
 	return diags
 }
 
+// resourceDataSourceImport accepts either a Data Source's ID or its unique name, which may be prefixed with "name:".
+func resourceDataSourceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return importByIdOrUniqueName(ctx, d, meta, "Data Source", func(ctx context.Context, c graphql.Client, id string) (string, error) {
+		response, err := pc.DataSource(ctx, c, id)
+		if err != nil || response.DataSource == nil {
+			return "", err
+		}
+
+		return response.DataSource.Id, nil
+	}, func(ctx context.Context, c graphql.Client, uniqueName string) (string, error) {
+		response, err := pc.DataSourceByName(ctx, c, uniqueName)
+		if err != nil || response.DataSource == nil {
+			return "", err
+		}
+
+		return response.DataSource.Id, nil
+	})
+}
+
+// reconnectDataSource reconnects the Data Source after its connection settings were modified, and waits for it to be
+// CONNECTED again.
+func reconnectDataSource(ctx context.Context, c graphql.Client, d *schema.ResourceData) diag.Diagnostics {
+	id := d.Id()
+
+	if _, err := pc.ReconnectDataSource(ctx, c, &pc.IdOrUniqueName{Id: &id}); err != nil {
+		return diagFromErr(err)
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	if err := waitForDataSourceConnected(ctx, c, id, timeout); err != nil {
+		return diagFromErr(err)
+	}
+
+	return nil
+}
+
+func resourceDataSourceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("table") || !d.NewValueKnown("table") {
+		return nil
+	}
+
+	switch normalizeDataSourceType(d.Get("type").(string)) {
+	case "HTTP", "S3":
+		oldTables, newTables := d.GetChange("table")
+		if tablesCanBeModified(oldTables.([]interface{}), newTables.([]interface{})) {
+			return nil
+		}
+	}
+
+	return forceNewNested(d, "table")
+}
+
+// tablesCanBeModified returns whether a Data Source's tables can be changed in place. Tables can be added, and
+// nullable columns can be added to existing tables, but existing tables and columns must be kept as they are.
+func tablesCanBeModified(oldTables, newTables []interface{}) bool {
+	tables := make(map[string]map[string]interface{}, len(newTables))
+	for _, rawTable := range newTables {
+		table := rawTable.(map[string]interface{})
+		tables[table["name"].(string)] = table
+	}
+
+	for _, rawOldTable := range oldTables {
+		oldTable := rawOldTable.(map[string]interface{})
+
+		newTable, ok := tables[oldTable["name"].(string)]
+		if !ok || oldTable["path"] != newTable["path"] {
+			return false
+		}
+
+		columns := columnsByName(newTable["column"].([]interface{}))
+
+		for _, rawOldColumn := range oldTable["column"].([]interface{}) {
+			oldColumn := rawOldColumn.(map[string]interface{})
+
+			newColumn, ok := columns[oldColumn["name"].(string)]
+			if !ok || !sameColumn(oldColumn, newColumn) {
+				return false
+			}
+
+			delete(columns, oldColumn["name"].(string))
+		}
+
+		// Rows that were already uploaded have no value for the added columns.
+		for _, column := range columns {
+			if !column["nullable"].(bool) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
 func waitForDataSourceConnected(ctx context.Context, client graphql.Client, id string, timeout time.Duration) error {
 	createStateConf := &resource.StateChangeConf{
 		Pending: []string{
@@ -666,20 +1299,20 @@ func waitForDataSourceConnected(ctx context.Context, client graphql.Client, id s
 		Refresh: func() (interface{}, string, error) {
 			resp, err := pc.DataSource(ctx, client, id)
 			if err != nil {
-				return nil, "", fmt.Errorf("error trying to read Data Source status: %s", err)
+				return nil, "", fmt.Errorf("error trying to read Data Source status: %w", err)
 			}
 
 			return resp, string(resp.DataSource.Status), nil
 		},
 		Timeout:                   timeout - time.Minute,
-		Delay:                     10 * time.Second,
-		MinTimeout:                5 * time.Second,
+		Delay:                     waitDelay,
+		MinTimeout:                waitMinTimeout,
 		ContinuousTargetOccurence: 3,
 	}
 
 	_, err := createStateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf("error waiting for Data Source to be CONNECTED: %s", err)
+		return fmt.Errorf("error waiting for Data Source to be CONNECTED: %w", err)
 	}
 
 	return nil
@@ -12,23 +12,24 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
+	"github.com/propeldata/terraform-provider-propel/propel/internal/fakepropel"
 	pc "github.com/propeldata/terraform-provider-propel/propel_client"
 )
 
 func TestAccPropelDataSourceBasic(t *testing.T) {
 	httpCtx := map[string]interface{}{
 		"resource_name": "new",
-		"unique_name":   acctest.RandString(10),
+		"unique_name":   testAccRandString(t, 10),
 	}
 
 	s3CtxInvalid := map[string]interface{}{
 		"resource_name": "fizz",
-		"unique_name":   acctest.RandString(10),
+		"unique_name":   testAccRandString(t, 10),
 	}
 
 	snowflakeCtxInvalid := map[string]interface{}{
 		"resource_name":       "foo",
-		"unique_name":         acctest.RandString(10),
+		"unique_name":         testAccRandString(t, 10),
 		"snowflake_account":   "invalid-account",
 		"snowflake_database":  "invalid-database",
 		"snowflake_warehouse": "invalid-warehouse",
@@ -38,6 +39,13 @@ func TestAccPropelDataSourceBasic(t *testing.T) {
 		"snowflake_password":  "invalid-password",
 	}
 
+	bigQueryCtxInvalid := map[string]interface{}{
+		"resource_name":       "buzz",
+		"unique_name":         testAccRandString(t, 10),
+		"bigquery_dataset_id": "invalid-dataset",
+		"bigquery_project_id": "invalid-project",
+	}
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProviderFactories,
@@ -70,6 +78,511 @@ func TestAccPropelDataSourceBasic(t *testing.T) {
 					resource.TestCheckResourceAttr("propel_data_source.foo", "status", "BROKEN"),
 				),
 			},
+			{
+				Config:      testAccCheckPropelDataSourceBigQueryConfigBroken(bigQueryCtxInvalid),
+				ExpectError: regexp.MustCompile(`unexpected state 'BROKEN'`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPropelDataSourceExists("propel_data_source.buzz"),
+					resource.TestCheckResourceAttr("propel_data_source.buzz", "type", "BIGQUERY"),
+					resource.TestCheckResourceAttr("propel_data_source.buzz", "status", "BROKEN"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitPropelDataSourceBasic(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+
+	ctx := map[string]interface{}{
+		"resource_name": "new",
+		"unique_name":   acctest.RandString(10),
+	}
+
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_data_source"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPropelDataSourceConfigBasic(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPropelDataSourceExists("propel_data_source.new"),
+					testUnitStoreID("propel_data_source.new", &id),
+					resource.TestCheckResourceAttr("propel_data_source.new", "unique_name", ctx["unique_name"].(string)),
+					resource.TestCheckResourceAttr("propel_data_source.new", "type", "Http"),
+					resource.TestCheckResourceAttr("propel_data_source.new", "status", "CONNECTED"),
+					resource.TestCheckResourceAttr("propel_data_source.new", "table.0.column.0.name", "timestamp_tz"),
+				),
+			},
+			{
+				ResourceName:      "propel_data_source.new",
+				ImportState:       true,
+				ImportStateId:     ctx["unique_name"].(string),
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "propel_data_source.new",
+				ImportState:   true,
+				ImportStateId: "name:does-not-exist",
+				ExpectError:   regexp.MustCompile(`no Data Source found with ID or unique name "does-not-exist"`),
+			},
+			{
+				// A Data Source deleted outside of Terraform is created again.
+				PreConfig: func() {
+					server.Remove(id)
+				},
+				Config: testAccCheckPropelDataSourceConfigBasic(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_source.new", "status", "CONNECTED"),
+					func(*terraform.State) error {
+						if n := server.Requests("CreateHttpDataSource"); n != 2 {
+							return fmt.Errorf("expected the Data Source to be created twice, got %d", n)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestUnitPropelDataSourceBroken(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+	server.SetTransitions(fakepropel.TypeDataSource, "CONNECTING", "BROKEN")
+
+	ctx := map[string]interface{}{
+		"resource_name": "fizz",
+		"unique_name":   acctest.RandString(10),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_data_source"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckPropelDataSourceS3ConfigBroken(ctx),
+				ExpectError: regexp.MustCompile(`unexpected state 'BROKEN'`),
+			},
+		},
+	})
+}
+
+func TestUnitPropelDataSourceSnowflakeUpdate(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+
+	ctx := map[string]interface{}{
+		"resource_name":       "foo",
+		"unique_name":         acctest.RandString(10),
+		"snowflake_account":   "account",
+		"snowflake_database":  "database",
+		"snowflake_warehouse": "warehouse",
+		"snowflake_schema":    "schema",
+		"snowflake_role":      "role",
+		"snowflake_username":  "username",
+		"snowflake_password":  "password",
+	}
+
+	rotated := make(map[string]interface{}, len(ctx))
+	for key, value := range ctx {
+		rotated[key] = value
+	}
+	rotated["snowflake_warehouse"] = "other-warehouse"
+	rotated["snowflake_password"] = "rotated-password"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_data_source"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPropelDataSourceSnowflakeConfigBroken(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_source.foo", "type", "Snowflake"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "status", "CONNECTED"),
+				),
+			},
+			{
+				// Rotating the password and switching warehouses updates the Data Source in place and reconnects it.
+				Config: testAccCheckPropelDataSourceSnowflakeConfigBroken(rotated),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_source.foo", "status", "CONNECTED"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "snowflake_connection_settings.0.warehouse", "other-warehouse"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "snowflake_connection_settings.0.password", "rotated-password"),
+					func(*terraform.State) error {
+						if n := server.Requests("CreateSnowflakeDataSource"); n != 1 {
+							return fmt.Errorf("expected the Data Source to be created once, got %d", n)
+						}
+
+						if n := server.Requests("ReconnectDataSource"); n != 1 {
+							return fmt.Errorf("expected the Data Source to be reconnected once, got %d", n)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "propel_data_source.foo",
+				ImportState:       true,
+				ImportStateId:     "name:" + ctx["unique_name"].(string),
+				ImportStateVerify: true,
+				// The password is never returned by the API.
+				ImportStateVerifyIgnore: []string{"snowflake_connection_settings.0.password"},
+			},
+		},
+	})
+}
+
+func TestUnitPropelDataSourceBigQueryUpdate(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+
+	ctx := map[string]interface{}{
+		"resource_name":       "buzz",
+		"unique_name":         acctest.RandString(10),
+		"bigquery_dataset_id": "dataset",
+		"bigquery_project_id": "project",
+	}
+
+	moved := make(map[string]interface{}, len(ctx))
+	for key, value := range ctx {
+		moved[key] = value
+	}
+	moved["bigquery_dataset_id"] = "other-dataset"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_data_source"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPropelDataSourceBigQueryConfigBroken(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_source.buzz", "type", "BIGQUERY"),
+					resource.TestCheckResourceAttr("propel_data_source.buzz", "status", "CONNECTED"),
+					resource.TestCheckResourceAttr("propel_data_source.buzz", "bigquery_connection_settings.0.dataset_id", "dataset"),
+					resource.TestCheckResourceAttr("propel_data_source.buzz", "bigquery_connection_settings.0.project_id", "project"),
+				),
+			},
+			{
+				// Switching datasets updates the Data Source in place and reconnects it.
+				Config: testAccCheckPropelDataSourceBigQueryConfigBroken(moved),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_source.buzz", "status", "CONNECTED"),
+					resource.TestCheckResourceAttr("propel_data_source.buzz", "bigquery_connection_settings.0.dataset_id", "other-dataset"),
+					func(*terraform.State) error {
+						if n := server.Requests("CreateBigQueryDataSource"); n != 1 {
+							return fmt.Errorf("expected the Data Source to be created once, got %d", n)
+						}
+
+						if n := server.Requests("ReconnectDataSource"); n != 1 {
+							return fmt.Errorf("expected the Data Source to be reconnected once, got %d", n)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "propel_data_source.buzz",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The credentials are never returned by the API.
+				ImportStateVerifyIgnore: []string{"bigquery_connection_settings.0.credentials_json"},
+			},
+		},
+	})
+}
+
+func TestUnitPropelDataSourceRedshiftUpdate(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+
+	ctx := map[string]interface{}{
+		"unique_name":       acctest.RandString(10),
+		"redshift_database": "database",
+		"redshift_password": "password",
+	}
+
+	rotated := map[string]interface{}{
+		"unique_name":       ctx["unique_name"],
+		"redshift_database": "other-database",
+		"redshift_password": "rotated-password",
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_data_source"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitPropelDataSourceRedshiftConfig(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_source.bar", "type", "Redshift"),
+					resource.TestCheckResourceAttr("propel_data_source.bar", "redshift_connection_settings.0.database", "database"),
+					resource.TestCheckResourceAttr("propel_data_source.bar", "redshift_connection_settings.0.port", "5439"),
+					resource.TestMatchResourceAttr("propel_data_source.bar", "propel_role_arn", regexp.MustCompile(`^arn:aws:iam::\d+:role/`)),
+				),
+			},
+			{
+				// Rotating the password and switching databases updates the Data Source in place and reconnects it.
+				Config: testUnitPropelDataSourceRedshiftConfig(rotated),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_source.bar", "redshift_connection_settings.0.database", "other-database"),
+					resource.TestCheckResourceAttr("propel_data_source.bar", "redshift_connection_settings.0.password", "rotated-password"),
+					resource.TestMatchResourceAttr("propel_data_source.bar", "propel_role_arn", regexp.MustCompile(`^arn:aws:iam::\d+:role/`)),
+					func(*terraform.State) error {
+						if n := server.Requests("CreateRedshiftDataSource"); n != 1 {
+							return fmt.Errorf("expected the Data Source to be created once, got %d", n)
+						}
+
+						if n := server.Requests("ReconnectDataSource"); n != 1 {
+							return fmt.Errorf("expected the Data Source to be reconnected once, got %d", n)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "propel_data_source.bar",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The password is never returned by the API.
+				ImportStateVerifyIgnore: []string{"redshift_connection_settings.0.password"},
+			},
+		},
+	})
+}
+
+func TestUnitPropelDataSourceHttpUpdate(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+
+	ctx := map[string]interface{}{
+		"unique_name":    acctest.RandString(10),
+		"timestamp_type": "TIMESTAMP",
+		"extra_column":   "",
+		"basic_auth_block": `basic_auth {
+				username = "user"
+				password = "secret"
+			}`,
+	}
+
+	withChanges := func(changes map[string]interface{}) map[string]interface{} {
+		changed := make(map[string]interface{}, len(ctx))
+		for key, value := range ctx {
+			changed[key] = value
+		}
+
+		for key, value := range changes {
+			changed[key] = value
+		}
+
+		return changed
+	}
+
+	addedColumn := withChanges(map[string]interface{}{
+		"extra_column": `column {
+				name = "account_id"
+				type = "STRING"
+				nullable = true
+			}`,
+		"basic_auth_block": `basic_auth {
+				username = "user"
+				password = "rotated"
+			}`,
+	})
+
+	withoutBasicAuth := withChanges(map[string]interface{}{
+		"extra_column":     addedColumn["extra_column"],
+		"basic_auth_block": "",
+	})
+
+	changedType := withChanges(map[string]interface{}{
+		"extra_column":     addedColumn["extra_column"],
+		"basic_auth_block": "",
+		"timestamp_type":   "DATE",
+	})
+
+	checkCreated := func(expected int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if n := server.Requests("CreateHttpDataSource"); n != expected {
+				return fmt.Errorf("expected the Data Source to be created %d times, got %d", expected, n)
+			}
+
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_data_source"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitPropelDataSourceHttpConfig(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_source.foo", "table.0.column.#", "1"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "http_connection_settings.0.basic_auth.0.password", "secret"),
+				),
+			},
+			{
+				// Nullable columns and Basic auth credentials are changed in place.
+				Config: testUnitPropelDataSourceHttpConfig(addedColumn),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_source.foo", "table.0.column.#", "2"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "table.0.column.1.name", "account_id"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "http_connection_settings.0.basic_auth.0.password", "rotated"),
+					checkCreated(1),
+				),
+			},
+			{
+				Config: testUnitPropelDataSourceHttpConfig(withoutBasicAuth),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_source.foo", "http_connection_settings.0.basic_auth.#", "0"),
+					checkCreated(1),
+				),
+			},
+			{
+				// Changing the type of an existing column replaces the Data Source.
+				Config: testUnitPropelDataSourceHttpConfig(changedType),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_source.foo", "table.0.column.0.type", "DATE"),
+					checkCreated(2),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitPropelDataSourceS3Update(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+
+	ctx := map[string]interface{}{
+		"unique_name":           acctest.RandString(10),
+		"aws_access_key_id":     "key-id",
+		"aws_secret_access_key": "secret",
+		"extra_column":          "",
+	}
+
+	rotated := map[string]interface{}{
+		"unique_name":           ctx["unique_name"],
+		"aws_access_key_id":     "rotated-key-id",
+		"aws_secret_access_key": "rotated-secret",
+		"extra_column": `column {
+				name = "account_id"
+				type = "STRING"
+				nullable = true
+			}`,
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_data_source"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitPropelDataSourceS3Config(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_source.foo", "s3_connection_settings.0.bucket", "bucket"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "s3_connection_settings.0.aws_access_key_id", "key-id"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "s3_connection_settings.0.aws_secret_access_key", "secret"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "table.0.path", "events/*.parquet"),
+				),
+			},
+			{
+				// Rotating the AWS keys and adding a nullable column updates the Data Source in place.
+				Config: testUnitPropelDataSourceS3Config(rotated),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_source.foo", "status", "CONNECTED"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "s3_connection_settings.0.aws_access_key_id", "rotated-key-id"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "s3_connection_settings.0.aws_secret_access_key", "rotated-secret"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "table.0.column.#", "2"),
+					func(*terraform.State) error {
+						if n := server.Requests("CreateS3DataSource"); n != 1 {
+							return fmt.Errorf("expected the Data Source to be created once, got %d", n)
+						}
+
+						if n := server.Requests("ReconnectDataSource"); n != 1 {
+							return fmt.Errorf("expected the Data Source to be reconnected once, got %d", n)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "propel_data_source.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The secret access key is never returned by the API.
+				ImportStateVerifyIgnore: []string{"s3_connection_settings.0.aws_secret_access_key"},
+			},
+		},
+	})
+}
+
+func TestUnitPropelDataSourceTablesPagination(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+	server.SetMaxPageSize(1)
+
+	ctx := map[string]interface{}{
+		"unique_name": acctest.RandString(10),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_data_source"),
+		Steps: []resource.TestStep{
+			{
+				Config: Nprintf(`
+				resource "propel_data_source" "foo" {
+					unique_name = "%{unique_name}"
+					type = "Http"
+
+					table {
+						name = "events"
+
+						column {
+							name = "timestamp_tz"
+							type = "TIMESTAMP"
+							nullable = false
+						}
+
+						column {
+							name = "account_id"
+							type = "STRING"
+							nullable = false
+						}
+
+						column {
+							name = "value"
+							type = "INT64"
+							nullable = true
+						}
+					}
+
+					table {
+						name = "accounts"
+
+						column {
+							name = "created_at"
+							type = "TIMESTAMP"
+							nullable = false
+						}
+
+						column {
+							name = "name"
+							type = "STRING"
+							nullable = true
+						}
+					}
+				}`, ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_data_source.foo", "table.#", "2"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "table.0.column.#", "3"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "table.0.column.2.name", "value"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "table.1.name", "accounts"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "table.1.column.#", "2"),
+					resource.TestCheckResourceAttr("propel_data_source.foo", "table.1.column.1.nullable", "true"),
+				),
+			},
 		},
 	})
 }
@@ -92,6 +605,57 @@ func testAccCheckPropelDataSourceConfigBasic(ctx map[string]interface{}) string
 	}`, ctx)
 }
 
+func testUnitPropelDataSourceHttpConfig(ctx map[string]interface{}) string {
+	return Nprintf(`
+	resource "propel_data_source" "foo" {
+		unique_name = "%{unique_name}"
+		type = "Http"
+
+		http_connection_settings {
+			%{basic_auth_block}
+		}
+
+		table {
+			name = "events"
+
+			column {
+				name = "timestamp_tz"
+				type = "%{timestamp_type}"
+				nullable = false
+			}
+
+			%{extra_column}
+		}
+	}`, ctx)
+}
+
+func testUnitPropelDataSourceS3Config(ctx map[string]interface{}) string {
+	return Nprintf(`
+	resource "propel_data_source" "foo" {
+		unique_name = "%{unique_name}"
+		type = "S3"
+
+		s3_connection_settings {
+			bucket = "bucket"
+			aws_access_key_id = "%{aws_access_key_id}"
+			aws_secret_access_key = "%{aws_secret_access_key}"
+		}
+
+		table {
+			name = "events"
+			path = "events/*.parquet"
+
+			column {
+				name = "timestamp_tz"
+				type = "TIMESTAMP"
+				nullable = false
+			}
+
+			%{extra_column}
+		}
+	}`, ctx)
+}
+
 func testAccCheckPropelDataSourceS3ConfigBroken(ctx map[string]interface{}) string {
 	return Nprintf(`
 	resource "propel_data_source" "%{resource_name}" {
@@ -135,6 +699,38 @@ func testAccCheckPropelDataSourceSnowflakeConfigBroken(ctx map[string]interface{
 	}`, ctx)
 }
 
+func testAccCheckPropelDataSourceBigQueryConfigBroken(ctx map[string]interface{}) string {
+	return Nprintf(`
+	resource "propel_data_source" "%{resource_name}" {
+		unique_name = "%{unique_name}"
+		type = "BIGQUERY"
+
+		bigquery_connection_settings {
+			credentials_json = "{}"
+			dataset_id = "%{bigquery_dataset_id}"
+			project_id = "%{bigquery_project_id}"
+		}
+	}`, ctx)
+}
+
+func testUnitPropelDataSourceRedshiftConfig(ctx map[string]interface{}) string {
+	return Nprintf(`
+	resource "propel_data_source" "bar" {
+		unique_name = "%{unique_name}"
+		type = "Redshift"
+
+		redshift_connection_settings {
+			host = "cluster.abc123.us-east-2.redshift.amazonaws.com"
+			port = 5439
+			database = "%{redshift_database}"
+			schema = "public"
+			username = "username"
+			password = "%{redshift_password}"
+			aws_account_id = "123456789012"
+		}
+	}`, ctx)
+}
+
 func testAccCheckPropelDataSourceDestroy(s *terraform.State) error {
 	c := testAccProvider.Meta().(graphql.Client)
 
@@ -146,7 +742,7 @@ func testAccCheckPropelDataSourceDestroy(s *terraform.State) error {
 		dataSourceID := rs.Primary.ID
 
 		_, err := pc.DeleteDataSource(context.Background(), c, dataSourceID)
-		if err != nil {
+		if err != nil && !pc.IsNotFound(err) {
 			return err
 		}
 	}
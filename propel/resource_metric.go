@@ -2,8 +2,11 @@ package propel
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -17,8 +20,9 @@ func resourceMetric() *schema.Resource {
 		ReadContext:   resourceMetricRead,
 		UpdateContext: resourceMetricUpdate,
 		DeleteContext: resourceMetricDelete,
+		CustomizeDiff: resourceMetricCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceMetricImport,
 		},
 		Description: "Provides a Propel Metric resource. This can be used to create and manage Propel Metrics.",
 		Schema: map[string]*schema.Schema{
@@ -42,6 +46,9 @@ func resourceMetric() *schema.Resource {
 					"SUM",
 					"COUNT",
 					"COUNT_DISTINCT",
+					"AVERAGE",
+					"MIN",
+					"MAX",
 				}, false),
 				Description: "The Metric type. The different Metric types determine how the values are calculated.",
 			},
@@ -55,12 +62,11 @@ func resourceMetric() *schema.Resource {
 			"data_pool": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "The Data Pool that powers this Metric.",
+				Description: "The Data Pool that powers this Metric. Changing the Data Pool migrates the Metric in place, so the new Data Pool must have the columns the Metric uses, both before and after any change to its dimensions and filters.",
 			},
 			"filter": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				ForceNew:    true,
 				Description: "Metric Filters allow defining a Metric with a subset of records from the given Data Pool. If no Metric Filters are present, all records will be included. To filter at query time, add Dimensions and use the `filters` property on the `timeSeriesInput`, `counterInput`, or `leaderboardInput` objects. There is no need to add `filters` to be able to filter at query time.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -94,7 +100,6 @@ func resourceMetric() *schema.Resource {
 				Type:        schema.TypeSet,
 				Optional:    true,
 				Computed:    true,
-				ForceNew:    true,
 				Description: "The Metric's Dimensions. These Dimensions are available to Query Filters.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
@@ -142,7 +147,7 @@ func resourceMetricCreate(ctx context.Context, d *schema.ResourceData, meta inte
 
 		response, err := pc.CreateSumMetric(ctx, c, input)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
 		d.SetId(response.GetCreateSumMetric().Metric.Id)
@@ -157,7 +162,7 @@ func resourceMetricCreate(ctx context.Context, d *schema.ResourceData, meta inte
 
 		response, err := pc.CreateCountMetric(ctx, c, input)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
 		d.SetId(response.GetCreateCountMetric().Metric.Id)
@@ -175,10 +180,64 @@ func resourceMetricCreate(ctx context.Context, d *schema.ResourceData, meta inte
 
 		response, err := pc.CreateCountDistinctMetric(ctx, c, input)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
 		d.SetId(response.GetCreateCountDistinctMetric().Metric.Id)
+	case "AVERAGE":
+		input := &pc.CreateAverageMetricInput{
+			DataPool:    d.Get("data_pool").(string),
+			UniqueName:  &uniqueName,
+			Description: &description,
+			Filters:     filters,
+			Dimensions:  dimensions,
+			Measure: &pc.DimensionInput{
+				ColumnName: d.Get("measure").(string),
+			},
+		}
+
+		response, err := pc.CreateAverageMetric(ctx, c, input)
+		if err != nil {
+			return diagFromErr(err)
+		}
+
+		d.SetId(response.GetCreateAverageMetric().Metric.Id)
+	case "MIN":
+		input := &pc.CreateMinMetricInput{
+			DataPool:    d.Get("data_pool").(string),
+			UniqueName:  &uniqueName,
+			Description: &description,
+			Filters:     filters,
+			Dimensions:  dimensions,
+			Measure: &pc.DimensionInput{
+				ColumnName: d.Get("measure").(string),
+			},
+		}
+
+		response, err := pc.CreateMinMetric(ctx, c, input)
+		if err != nil {
+			return diagFromErr(err)
+		}
+
+		d.SetId(response.GetCreateMinMetric().Metric.Id)
+	case "MAX":
+		input := &pc.CreateMaxMetricInput{
+			DataPool:    d.Get("data_pool").(string),
+			UniqueName:  &uniqueName,
+			Description: &description,
+			Filters:     filters,
+			Dimensions:  dimensions,
+			Measure: &pc.DimensionInput{
+				ColumnName: d.Get("measure").(string),
+			},
+		}
+
+		response, err := pc.CreateMaxMetric(ctx, c, input)
+		if err != nil {
+			return diagFromErr(err)
+		}
+
+		d.SetId(response.GetCreateMaxMetric().Metric.Id)
 	}
 
 	return diags
@@ -191,7 +250,19 @@ func resourceMetricRead(ctx context.Context, d *schema.ResourceData, meta interf
 
 	response, err := pc.Metric(ctx, c, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		if pc.IsNotFound(err) {
+			tflog.Warn(ctx, "Metric not found, removing from state", map[string]interface{}{"id": d.Id()})
+			d.SetId("")
+			return nil
+		}
+
+		return diagFromErr(err)
+	}
+
+	if response.Metric == nil {
+		tflog.Warn(ctx, "Metric not found, removing from state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
 	}
 
 	d.SetId(response.Metric.Id)
@@ -225,13 +296,7 @@ func resourceMetricRead(ctx context.Context, d *schema.ResourceData, meta interf
 	switch s := response.Metric.Settings.(type) {
 	case *pc.MetricDataSettingsCountMetricSettings:
 		for _, f := range s.Filters {
-			filter := map[string]interface{}{
-				"column":   f.Column,
-				"operator": f.Operator,
-				"value":    f.Value,
-			}
-
-			filters = append(filters, filter)
+			filters = append(filters, flattenMetricFilter(f.FilterData))
 		}
 	case *pc.MetricDataSettingsSumMetricSettings:
 		if err := d.Set("measure", s.Measure.ColumnName); err != nil {
@@ -239,13 +304,7 @@ func resourceMetricRead(ctx context.Context, d *schema.ResourceData, meta interf
 		}
 
 		for _, f := range s.Filters {
-			filter := map[string]interface{}{
-				"column":   f.Column,
-				"operator": f.Operator,
-				"value":    f.Value,
-			}
-
-			filters = append(filters, filter)
+			filters = append(filters, flattenMetricFilter(f.FilterData))
 		}
 	case *pc.MetricDataSettingsCountDistinctMetricSettings:
 		if err := d.Set("dimension", s.Dimension.ColumnName); err != nil {
@@ -253,13 +312,31 @@ func resourceMetricRead(ctx context.Context, d *schema.ResourceData, meta interf
 		}
 
 		for _, f := range s.Filters {
-			filter := map[string]interface{}{
-				"column":   f.Column,
-				"operator": f.Operator,
-				"value":    f.Value,
-			}
+			filters = append(filters, flattenMetricFilter(f.FilterData))
+		}
+	case *pc.MetricDataSettingsAverageMetricSettings:
+		if err := d.Set("measure", s.Measure.ColumnName); err != nil {
+			return diag.FromErr(err)
+		}
 
-			filters = append(filters, filter)
+		for _, f := range s.Filters {
+			filters = append(filters, flattenMetricFilter(f.FilterData))
+		}
+	case *pc.MetricDataSettingsMinMetricSettings:
+		if err := d.Set("measure", s.Measure.ColumnName); err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, f := range s.Filters {
+			filters = append(filters, flattenMetricFilter(f.FilterData))
+		}
+	case *pc.MetricDataSettingsMaxMetricSettings:
+		if err := d.Set("measure", s.Measure.ColumnName); err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, f := range s.Filters {
+			filters = append(filters, flattenMetricFilter(f.FilterData))
 		}
 	}
 
@@ -273,18 +350,40 @@ func resourceMetricRead(ctx context.Context, d *schema.ResourceData, meta interf
 func resourceMetricUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(graphql.Client)
 
-	if d.HasChanges("unique_name", "description") {
+	if d.HasChange("data_pool") {
+		dataPoolId := d.Get("data_pool").(string)
+
+		if err := checkMetricMigration(ctx, c, dataPoolId, metricMigrationColumns(d)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		input := &pc.MigrateMetricInput{
+			MetricId:      d.Id(),
+			NewDataPoolId: dataPoolId,
+		}
+
+		_, err := pc.MigrateMetric(ctx, c, input)
+		if err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	if d.HasChanges("unique_name", "description", "filter", "dimensions") {
 		uniqueName := d.Get("unique_name").(string)
 		description := d.Get("description").(string)
+		// The Dimensions and Filters are always sent, and null would remove them, so they are always set from the
+		// configuration.
 		modifyMetric := &pc.ModifyMetricInput{
 			Metric:      d.Id(),
 			UniqueName:  &uniqueName,
 			Description: &description,
+			Dimensions:  expandMetricDimensions(d.Get("dimensions").(*schema.Set).List()),
+			Filters:     expandMetricFilters(d.Get("filter").([]interface{})),
 		}
 
 		_, err := pc.ModifyMetric(ctx, c, modifyMetric)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -296,7 +395,7 @@ func resourceMetricDelete(ctx context.Context, d *schema.ResourceData, m interfa
 
 	_, err := pc.DeleteMetric(ctx, c, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId("")
@@ -304,6 +403,102 @@ func resourceMetricDelete(ctx context.Context, d *schema.ResourceData, m interfa
 	return nil
 }
 
+// resourceMetricImport accepts either a Metric's ID or its unique name, which may be prefixed with "name:".
+func resourceMetricImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return importByIdOrUniqueName(ctx, d, meta, "Metric", func(ctx context.Context, c graphql.Client, id string) (string, error) {
+		response, err := pc.Metric(ctx, c, id)
+		if err != nil || response.Metric == nil {
+			return "", err
+		}
+
+		return response.Metric.Id, nil
+	}, func(ctx context.Context, c graphql.Client, uniqueName string) (string, error) {
+		response, err := pc.MetricByName(ctx, c, uniqueName)
+		if err != nil || response.Metric == nil {
+			return "", err
+		}
+
+		return response.Metric.Id, nil
+	})
+}
+
+func resourceMetricCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Only a migration of an existing Metric to an existing Data Pool can be checked at plan time. Otherwise, it is
+	// checked when the Metric is updated.
+	if d.Id() == "" || !d.HasChange("data_pool") || !d.NewValueKnown("data_pool") {
+		return nil
+	}
+
+	c := meta.(graphql.Client)
+
+	return checkMetricMigration(ctx, c, d.Get("data_pool").(string), metricMigrationColumns(d))
+}
+
+// checkMetricMigration checks that the Data Pool a Metric is migrated to has all the columns the Metric uses.
+func checkMetricMigration(ctx context.Context, c graphql.Client, dataPoolId string, required []string) error {
+	dataPoolColumns, err := pc.FetchDataPoolColumns(ctx, c, dataPoolId)
+	if err != nil {
+		return fmt.Errorf("error reading Data Pool %s: %s", dataPoolId, err)
+	}
+
+	columns := make(map[string]bool, len(dataPoolColumns))
+	for _, column := range dataPoolColumns {
+		columns[column.ColumnName] = true
+	}
+
+	missing := make([]string, 0)
+	for _, column := range required {
+		if !columns[column] {
+			missing = append(missing, column)
+			// Only report each missing column once.
+			columns[column] = true
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("cannot migrate Metric to Data Pool %s: missing columns %s, which the Metric uses before or after the change", dataPoolId, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// metricMigrationColumns returns the columns the Metric uses before and after the change. The Metric is migrated
+// before its dimensions and filters are modified, so the Data Pool it is migrated to needs all of them.
+func metricMigrationColumns(d interface {
+	GetChange(string) (interface{}, interface{})
+}) []string {
+	oldColumns := metricColumns(func(key string) interface{} {
+		o, _ := d.GetChange(key)
+		return o
+	})
+	newColumns := metricColumns(func(key string) interface{} {
+		_, n := d.GetChange(key)
+		return n
+	})
+
+	return append(oldColumns, newColumns...)
+}
+
+// metricColumns returns the columns used by the Metric's measure, dimension, dimensions and filters.
+func metricColumns(get func(key string) interface{}) []string {
+	columns := make([]string, 0)
+	for _, key := range []string{"measure", "dimension"} {
+		if column := get(key).(string); column != "" {
+			columns = append(columns, column)
+		}
+	}
+
+	for _, column := range get("dimensions").(*schema.Set).List() {
+		columns = append(columns, column.(string))
+	}
+
+	for _, rawFilter := range get("filter").([]interface{}) {
+		columns = append(columns, rawFilter.(map[string]interface{})["column"].(string))
+	}
+
+	return columns
+}
+
 func expandMetricFilters(def []interface{}) []*pc.FilterInput {
 	filters := make([]*pc.FilterInput, 0, len(def))
 
@@ -339,6 +534,14 @@ func expandMetricFilters(def []interface{}) []*pc.FilterInput {
 	return filters
 }
 
+func flattenMetricFilter(f pc.FilterData) map[string]interface{} {
+	return map[string]interface{}{
+		"column":   f.Column,
+		"operator": f.Operator,
+		"value":    f.Value,
+	}
+}
+
 func expandMetricDimensions(def []interface{}) []*pc.DimensionInput {
 	dimensions := make([]*pc.DimensionInput, 0, len(def))
 
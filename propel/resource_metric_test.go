@@ -0,0 +1,421 @@
+package propel
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestUnitPropelMetricBasic(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+
+	ctx := map[string]interface{}{
+		"unique_name":  acctest.RandString(10),
+		"filter_value": "foo",
+	}
+
+	updatedCtx := map[string]interface{}{
+		"unique_name":  ctx["unique_name"],
+		"filter_value": "bar",
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_metric"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitPropelMetricConfigBasic(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("propel_metric.baz", "id"),
+					resource.TestCheckResourceAttr("propel_metric.baz", "type", "SUM"),
+					resource.TestCheckResourceAttr("propel_metric.baz", "measure", "value"),
+					resource.TestCheckResourceAttr("propel_metric.baz", "dimensions.#", "1"),
+					resource.TestCheckResourceAttr("propel_metric.baz", "filter.0.value", "foo"),
+					resource.TestCheckResourceAttrPair("propel_metric.baz", "data_pool", "propel_data_pool.bar", "id"),
+				),
+			},
+			{
+				Config: testUnitPropelMetricConfigBasic(updatedCtx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_metric.baz", "filter.0.value", "bar"),
+					func(*terraform.State) error {
+						if n := server.Requests("CreateSumMetric"); n != 1 {
+							return fmt.Errorf("expected the Metric to be modified in place, but it was created %d times", n)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "propel_metric.baz",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "propel_metric.baz",
+				ImportState:       true,
+				ImportStateId:     "name:" + ctx["unique_name"].(string),
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "propel_metric.baz",
+				ImportState:       true,
+				ImportStateId:     ctx["unique_name"].(string),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitPropelMetricMeasureTypes(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+
+	uniqueName := acctest.RandString(10)
+
+	steps := make([]resource.TestStep, 0)
+	mutations := map[string]string{
+		"AVERAGE": "CreateAverageMetric",
+		"MIN":     "CreateMinMetric",
+		"MAX":     "CreateMaxMetric",
+	}
+
+	for _, metricType := range []string{"AVERAGE", "MIN", "MAX"} {
+		mutation := mutations[metricType]
+
+		ctx := map[string]interface{}{
+			"unique_name": uniqueName,
+			"type":        metricType,
+		}
+
+		steps = append(steps, resource.TestStep{
+			// Changing the type replaces the Metric.
+			Config: testUnitPropelMetricConfigMeasure(ctx),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("propel_metric.baz", "type", metricType),
+				resource.TestCheckResourceAttr("propel_metric.baz", "measure", "value"),
+				resource.TestCheckResourceAttr("propel_metric.baz", "filter.#", "1"),
+				resource.TestCheckResourceAttr("propel_metric.baz", "filter.0.column", "account_id"),
+				resource.TestCheckResourceAttr("propel_metric.baz", "filter.0.operator", "NOT_EQUALS"),
+				resource.TestCheckResourceAttr("propel_metric.baz", "filter.0.value", "internal"),
+				func(*terraform.State) error {
+					if n := server.Requests(mutation); n != 1 {
+						return fmt.Errorf("expected %s to be requested once, got %d", mutation, n)
+					}
+
+					return nil
+				},
+			),
+		})
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_metric"),
+		Steps:             steps,
+	})
+}
+
+func testUnitPropelMetricConfigBasic(ctx map[string]interface{}) string {
+	return testUnitPropelMetricConfigDataPool(ctx) + Nprintf(`
+
+	resource "propel_metric" "baz" {
+		unique_name = "%{unique_name}"
+		type = "SUM"
+		data_pool = propel_data_pool.bar.id
+		measure = "value"
+		dimensions = ["account_id"]
+
+		filter {
+			column = "account_id"
+			operator = "EQUALS"
+			value = "%{filter_value}"
+		}
+	}`, ctx)
+}
+
+// testUnitPropelMetricConfigDataPool returns the Data Source and Data Pool that Metrics are built on.
+func testUnitPropelMetricConfigDataPool(ctx map[string]interface{}) string {
+	return Nprintf(`
+	resource "propel_data_source" "foo" {
+		unique_name = "%{unique_name}"
+		type = "Http"
+
+		table {
+			name = "events"
+
+			column {
+				name = "timestamp_tz"
+				type = "TIMESTAMP"
+				nullable = false
+			}
+
+			column {
+				name = "account_id"
+				type = "STRING"
+				nullable = false
+			}
+
+			column {
+				name = "value"
+				type = "INT64"
+				nullable = false
+			}
+		}
+	}
+
+	resource "propel_data_pool" "bar" {
+		unique_name = "%{unique_name}"
+		table = propel_data_source.foo.table[0].name
+		timestamp = "timestamp_tz"
+		data_source = propel_data_source.foo.id
+
+		column {
+			name = "timestamp_tz"
+			type = "TIMESTAMP"
+			nullable = false
+		}
+
+		column {
+			name = "account_id"
+			type = "STRING"
+			nullable = false
+		}
+
+		column {
+			name = "value"
+			type = "INT64"
+			nullable = false
+		}
+	}`, ctx)
+}
+
+func TestUnitPropelMetricMigration(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+	// A page per column makes the migration check page through the Data Pool's columns.
+	server.SetMaxPageSize(1)
+
+	ctx := map[string]interface{}{
+		"unique_name": acctest.RandString(10),
+		"data_pool":   "bar",
+	}
+
+	migrated := map[string]interface{}{
+		"unique_name": ctx["unique_name"],
+		"data_pool":   "qux",
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_metric"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitPropelMetricConfigMigration(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("propel_metric.baz", "data_pool", "propel_data_pool.bar", "id"),
+				),
+			},
+			{
+				// Both Data Pools have the Metric's columns, so it is migrated in place.
+				Config: testUnitPropelMetricConfigMigration(migrated),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("propel_metric.baz", "data_pool", "propel_data_pool.qux", "id"),
+					func(*terraform.State) error {
+						if n := server.Requests("CreateSumMetric"); n != 1 {
+							return fmt.Errorf("expected the Metric to be migrated in place, but it was created %d times", n)
+						}
+
+						if n := server.Requests("MigrateMetric"); n != 1 {
+							return fmt.Errorf("expected the Metric to be migrated once, got %d", n)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				// A Data Pool that already exists is checked at plan time.
+				Config: testUnitPropelMetricConfigMigration(map[string]interface{}{
+					"unique_name": ctx["unique_name"],
+					"data_pool":   "short",
+				}),
+				ExpectError: regexp.MustCompile(`missing columns value, account_id`),
+			},
+			{
+				// A Data Pool created in the same apply is checked before the Metric is migrated.
+				Config: testUnitPropelMetricConfigMigration(map[string]interface{}{
+					"unique_name": ctx["unique_name"],
+					"data_pool":   "late",
+				}) + `
+
+				resource "propel_data_pool" "late" {
+					unique_name = "late"
+					table = propel_data_source.foo.table[0].name
+					timestamp = "timestamp_tz"
+					data_source = propel_data_source.foo.id
+
+					column {
+						name = "timestamp_tz"
+						type = "TIMESTAMP"
+						nullable = false
+					}
+				}`,
+				ExpectError: regexp.MustCompile(`missing columns value, account_id`),
+			},
+			{
+				Config: testUnitPropelMetricConfigMigration(migrated),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("propel_metric.baz", "data_pool", "propel_data_pool.qux", "id"),
+					func(*terraform.State) error {
+						if n := server.Requests("MigrateMetric"); n != 1 {
+							return fmt.Errorf("expected no further migrations, got %d", n)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				// The Metric is migrated before its dimensions and filters change, so the Data Pool also needs the
+				// columns of the ones being removed.
+				Config: testUnitPropelMetricConfigMigration(map[string]interface{}{
+					"unique_name":        ctx["unique_name"],
+					"data_pool":          "values",
+					"without_account_id": true,
+				}),
+				ExpectError: regexp.MustCompile(`missing columns account_id, which`),
+			},
+			{
+				// Once they are removed, the Metric can be migrated.
+				Config: testUnitPropelMetricConfigMigration(map[string]interface{}{
+					"unique_name":        ctx["unique_name"],
+					"data_pool":          "qux",
+					"without_account_id": true,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_metric.baz", "dimensions.#", "0"),
+					resource.TestCheckResourceAttr("propel_metric.baz", "filter.#", "0"),
+				),
+			},
+			{
+				Config: testUnitPropelMetricConfigMigration(map[string]interface{}{
+					"unique_name":        ctx["unique_name"],
+					"data_pool":          "values",
+					"without_account_id": true,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("propel_metric.baz", "data_pool", "propel_data_pool.values", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testUnitPropelMetricConfigMigration(ctx map[string]interface{}) string {
+	pool := `
+	resource "propel_data_pool" "%s" {
+		unique_name = "%s"
+		table = propel_data_source.foo.table[0].name
+		timestamp = "timestamp_tz"
+		data_source = propel_data_source.foo.id
+
+		column {
+			name = "timestamp_tz"
+			type = "TIMESTAMP"
+			nullable = false
+		}
+	%s}
+`
+	columns := `
+		column {
+			name = "account_id"
+			type = "STRING"
+			nullable = false
+		}
+
+		column {
+			name = "value"
+			type = "INT64"
+			nullable = false
+		}
+	`
+
+	valueColumn := `
+		column {
+			name = "value"
+			type = "INT64"
+			nullable = false
+		}
+	`
+
+	ctx["dimensions"] = `["account_id"]`
+	ctx["filter"] = `filter {
+			column = "account_id"
+			operator = "EQUALS"
+			value = "foo"
+		}`
+	if ctx["without_account_id"] == true {
+		ctx["dimensions"] = "[]"
+		ctx["filter"] = ""
+	}
+
+	return Nprintf(`
+	resource "propel_data_source" "foo" {
+		unique_name = "%{unique_name}"
+		type = "Http"
+
+		table {
+			name = "events"
+
+			column {
+				name = "timestamp_tz"
+				type = "TIMESTAMP"
+				nullable = false
+			}
+
+			column {
+				name = "account_id"
+				type = "STRING"
+				nullable = false
+			}
+
+			column {
+				name = "value"
+				type = "INT64"
+				nullable = false
+			}
+		}
+	}
+	`+fmt.Sprintf(pool, "bar", "bar", columns)+fmt.Sprintf(pool, "qux", "qux", columns)+fmt.Sprintf(pool, "short", "short", "")+
+		fmt.Sprintf(pool, "values", "values", valueColumn)+`
+	resource "propel_metric" "baz" {
+		unique_name = "%{unique_name}"
+		type = "SUM"
+		data_pool = propel_data_pool.%{data_pool}.id
+		measure = "value"
+		dimensions = %{dimensions}
+
+		%{filter}
+	}`, ctx)
+}
+
+func testUnitPropelMetricConfigMeasure(ctx map[string]interface{}) string {
+	return testUnitPropelMetricConfigDataPool(ctx) + Nprintf(`
+
+	resource "propel_metric" "baz" {
+		unique_name = "%{unique_name}"
+		type = "%{type}"
+		data_pool = propel_data_pool.bar.id
+		measure = "value"
+
+		filter {
+			column = "account_id"
+			operator = "NOT_EQUALS"
+			value = "internal"
+		}
+	}`, ctx)
+}
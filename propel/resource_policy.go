@@ -0,0 +1,155 @@
+package propel
+
+import (
+	"context"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	pc "github.com/propeldata/terraform-provider-propel/propel_client"
+)
+
+func resourcePolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePolicyCreate,
+		ReadContext:   resourcePolicyRead,
+		UpdateContext: resourcePolicyUpdate,
+		DeleteContext: resourcePolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Description: "Provides a Propel Policy resource. This can be used to grant an Application access to a Metric's data.",
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"ALL_ACCESS",
+					"TENANT_ACCESS",
+				}, false),
+				Description: "The type of Policy. `ALL_ACCESS` grants access to all of the Metric's data, while `TENANT_ACCESS` grants access to a specified tenant's data.",
+			},
+			"metric": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The Metric that the Application is granted access to.",
+			},
+			"application": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The Application that is granted access.",
+			},
+			"account": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Account that the Policy belongs to.",
+			},
+			"environment": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Environment that the Policy belongs to.",
+			},
+		},
+	}
+}
+
+func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	input := &pc.CreatePolicyInput{
+		Metric:      d.Get("metric").(string),
+		Type:        pc.PolicyType(d.Get("type").(string)),
+		Application: d.Get("application").(string),
+	}
+
+	response, err := pc.CreatePolicy(ctx, c, input)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId(response.CreatePolicy.Policy.Id)
+
+	return resourcePolicyRead(ctx, d, meta)
+}
+
+func resourcePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	var diags diag.Diagnostics
+
+	response, err := pc.Policy(ctx, c, d.Id())
+	if err != nil {
+		if pc.IsNotFound(err) {
+			tflog.Warn(ctx, "Policy not found, removing from state", map[string]interface{}{"id": d.Id()})
+			d.SetId("")
+			return nil
+		}
+
+		return diagFromErr(err)
+	}
+
+	if response.Policy == nil {
+		tflog.Warn(ctx, "Policy not found, removing from state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(response.Policy.Id)
+	if err := d.Set("type", response.Policy.Type); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("metric", response.Policy.Metric.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("application", response.Policy.Application.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("account", response.Policy.Account.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("environment", response.Policy.Environment.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourcePolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	if d.HasChange("type") {
+		input := &pc.ModifyPolicyInput{
+			Policy: d.Id(),
+			Type:   pc.PolicyType(d.Get("type").(string)),
+		}
+
+		_, err := pc.ModifyPolicy(ctx, c, input)
+		if err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	return resourcePolicyRead(ctx, d, meta)
+}
+
+func resourcePolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(graphql.Client)
+
+	_, err := pc.DeletePolicy(ctx, c, d.Id())
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package propel
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestUnitPropelPolicyBasic(t *testing.T) {
+	server, providerFactories := testUnitFakePropel(t)
+
+	ctx := map[string]interface{}{
+		"unique_name":  acctest.RandString(10),
+		"filter_value": "foo",
+		"propeller":    "P1_X_SMALL",
+		"policy_type":  "ALL_ACCESS",
+	}
+
+	updatedCtx := make(map[string]interface{}, len(ctx))
+	for key, value := range ctx {
+		updatedCtx[key] = value
+	}
+	updatedCtx["policy_type"] = "TENANT_ACCESS"
+
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testUnitCheckDestroy(server, "propel_policy"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitPropelPolicyConfigBasic(ctx),
+				Check: resource.ComposeTestCheckFunc(
+					testUnitStoreID("propel_policy.qux", &id),
+					resource.TestCheckResourceAttr("propel_policy.qux", "type", "ALL_ACCESS"),
+					resource.TestCheckResourceAttrPair("propel_policy.qux", "metric", "propel_metric.baz", "id"),
+					resource.TestCheckResourceAttrPair("propel_policy.qux", "application", "propel_application.foo", "id"),
+				),
+			},
+			{
+				// Changing the type modifies the Policy in place.
+				Config: testUnitPropelPolicyConfigBasic(updatedCtx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_policy.qux", "type", "TENANT_ACCESS"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["propel_policy.qux"].Primary.ID != id {
+							return errors.New("expected the Policy to be modified in place, but it was replaced")
+						}
+
+						if n := server.Requests("ModifyPolicy"); n != 1 {
+							return fmt.Errorf("expected the Policy to be modified once, got %d", n)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "propel_policy.qux",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// A Policy deleted outside of Terraform is removed from state and created again.
+				PreConfig: func() {
+					server.Remove(id)
+				},
+				Config: testUnitPropelPolicyConfigBasic(updatedCtx),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("propel_policy.qux", "type", "TENANT_ACCESS"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["propel_policy.qux"].Primary.ID == id {
+							return errors.New("expected the deleted Policy to be created again")
+						}
+
+						if n := server.Requests("CreatePolicy"); n != 2 {
+							return fmt.Errorf("expected the Policy to be created twice, got %d", n)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testUnitPropelPolicyConfigBasic(ctx map[string]interface{}) string {
+	return testUnitPropelMetricConfigBasic(ctx) + testAccCheckPropelApplicationConfigBasic(ctx) + Nprintf(`
+
+	resource "propel_policy" "qux" {
+		type = "%{policy_type}"
+		metric = propel_metric.baz.id
+		application = propel_application.foo.id
+	}`, ctx)
+}
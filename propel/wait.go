@@ -0,0 +1,14 @@
+package propel
+
+import "time"
+
+// How often the provider polls the Propel API while waiting for an object to change status. These are variables so
+// that tests against a fake API don't have to wait.
+var (
+	// waitDelay is how long to wait before the first poll.
+	waitDelay = 10 * time.Second
+	// waitMinTimeout is the minimum time between polls.
+	waitMinTimeout = 5 * time.Second
+	// deletionPollInterval is the time between polls while waiting for a Data Pool to be deleted.
+	deletionPollInterval = 10 * time.Second
+)
@@ -0,0 +1,292 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CassetteMode selects whether a Cassette records the exchanges with the Propel API or replays recorded ones.
+type CassetteMode string
+
+const (
+	CassetteModeRecord CassetteMode = "record"
+	CassetteModeReplay CassetteMode = "replay"
+)
+
+// CassetteSecret is the placeholder that secrets are scrubbed to. Tests that record cassettes use it as the secrets
+// in their configuration, so that the secrets the provider reads back from a replayed response match it.
+const CassetteSecret = redacted
+
+// Cassette holds the OAuth and GraphQL exchanges of a test, so that the test can later run without access to the
+// Propel API. Credentials, tokens and other secrets are scrubbed before an exchange is stored.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+
+	mode CassetteMode
+	path string
+
+	mu sync.Mutex
+	// replayed marks the interactions that were already replayed, so that repeated requests, such as the polls
+	// while waiting for a status, get their responses in the order they were recorded.
+	replayed []bool
+}
+
+// Interaction is a request to the Propel API and the response it got.
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is a scrubbed request. For GraphQL requests, Body only has the operation name and variables.
+type CassetteRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body"`
+}
+
+// CassetteResponse is a scrubbed response.
+type CassetteResponse struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body"`
+}
+
+// recordedHeaders are the response headers kept in a cassette.
+var recordedHeaders = []string{"Content-Type", "Retry-After"}
+
+// NewCassette returns an empty cassette that records to the file at path when saved.
+func NewCassette(path string) *Cassette {
+	return &Cassette{
+		mode: CassetteModeRecord,
+		path: path,
+	}
+}
+
+// LoadCassette loads the cassette recorded at path, to replay it.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cassette: %w", err)
+	}
+
+	c := &Cassette{
+		mode: CassetteModeReplay,
+		path: path,
+	}
+
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("unable to parse cassette %s: %w", path, err)
+	}
+
+	c.replayed = make([]bool, len(c.Interactions))
+
+	return c, nil
+}
+
+// Mode returns whether the cassette records or replays.
+func (c *Cassette) Mode() CassetteMode {
+	return c.mode
+}
+
+// Save writes the recorded interactions to the cassette's file.
+func (c *Cassette) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var data bytes.Buffer
+
+	encoder := json.NewEncoder(&data)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(c); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.path, data.Bytes(), 0o644)
+}
+
+// withCassette is an http.RoundTripper that records the exchanges going through it to a cassette, or replays them
+// from it without sending anything.
+type withCassette struct {
+	cassette  *Cassette
+	transport http.RoundTripper
+}
+
+// noRecordedInteractionError is returned when a replayed request was not recorded. Retrying it cannot help.
+type noRecordedInteractionError struct {
+	path    string
+	request CassetteRequest
+}
+
+func (e *noRecordedInteractionError) Error() string {
+	return fmt.Sprintf("cassette %s has no recorded response for %s %s %s", e.path, e.request.Method, e.request.URL, e.request.Body)
+}
+
+func (wc *withCassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	request := CassetteRequest{
+		Method: req.Method,
+		URL:    req.URL.Scheme + "://" + req.URL.Host + req.URL.Path,
+		Body:   scrubRequestBody(req.Header.Get("Content-Type"), body),
+	}
+
+	if wc.cassette.mode == CassetteModeReplay {
+		return wc.cassette.replay(req, request)
+	}
+
+	resp, err := wc.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	if err != nil {
+		return resp, err
+	}
+
+	response := CassetteResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    map[string]string{},
+		Body:       scrubResponseBody(respBody),
+	}
+
+	for _, header := range recordedHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			response.Headers[header] = value
+		}
+	}
+
+	wc.cassette.mu.Lock()
+	wc.cassette.Interactions = append(wc.cassette.Interactions, &Interaction{Request: request, Response: response})
+	wc.cassette.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay returns the response to the first matching request that was not replayed yet. Requests match on their
+// method, URL path and scrubbed body, so that a cassette recorded against one region replays against any.
+func (c *Cassette) replay(req *http.Request, request CassetteRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, interaction := range c.Interactions {
+		if c.replayed[i] || !requestsMatch(interaction.Request, request) {
+			continue
+		}
+
+		c.replayed[i] = true
+
+		header := http.Header{}
+		for key, value := range interaction.Response.Headers {
+			header.Set(key, value)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, &noRecordedInteractionError{path: c.path, request: request}
+}
+
+func requestsMatch(recorded, request CassetteRequest) bool {
+	if recorded.Method != request.Method || recorded.Body != request.Body {
+		return false
+	}
+
+	recordedURL, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+
+	requestURL, err := url.Parse(request.URL)
+	if err != nil {
+		return false
+	}
+
+	return recordedURL.Path == requestURL.Path
+}
+
+// scrubRequestBody returns the body of a request with its secrets replaced. The client credentials of OAuth
+// requests are replaced, as they identify the account. GraphQL requests are reduced to their operation name and
+// variables, since the query text is generated.
+func scrubRequestBody(contentType string, body []byte) string {
+	if contentType == "application/x-www-form-urlencoded" {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return redacted
+		}
+
+		for _, key := range []string{"client_id", "client_secret"} {
+			if values.Has(key) {
+				values.Set(key, redacted)
+			}
+		}
+
+		return values.Encode()
+	}
+
+	var operation graphQLRequest
+	if err := json.Unmarshal(body, &operation); err != nil || operation.OperationName == "" {
+		return redactSecrets(string(body))
+	}
+
+	scrubbed, err := json.Marshal(graphQLRequest{
+		OperationName: operation.OperationName,
+		Variables:     redactVariables(operation.Variables),
+	})
+	if err != nil {
+		return redacted
+	}
+
+	return string(scrubbed)
+}
+
+// scrubResponseBody returns the body of a response with the values of sensitive keys, such as access tokens and
+// Application secrets, replaced.
+func scrubResponseBody(body []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return redactSecrets(string(body))
+	}
+
+	scrubbed, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return redacted
+	}
+
+	return string(scrubbed)
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestPropelServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "live-token", "expires_in": 3600}`))
+	})
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"application": {"id": "APP01", "uniqueName": "My Application", "secret": "hunter2"}}}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestCassetteRecordsAndReplays(t *testing.T) {
+	server := newTestPropelServer(t)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	opts := ClientOptions{
+		ApiURL:   server.URL + "/graphql",
+		OAuthURL: server.URL + "/oauth2/token",
+		Cassette: NewCassette(path),
+	}
+
+	client, err := NewPropelClient("client-id", "client-secret", "test", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Application(context.Background(), client, "APP01"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := opts.Cassette.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	recorded, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{"client-id", "client-secret", "live-token", "hunter2"} {
+		if strings.Contains(string(recorded), secret) {
+			t.Fatalf("expected %q to be scrubbed from %s", secret, recorded)
+		}
+	}
+
+	// Replaying must not reach the API.
+	server.Close()
+
+	if opts.Cassette, err = LoadCassette(path); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err = NewPropelClient("other-client-id", "other-client-secret", "test", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := Application(context.Background(), client, "APP01")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Application.UniqueName != "My Application" {
+		t.Fatalf("unexpected replayed Application %+v", resp.Application)
+	}
+}
+
+func TestCassetteReplayFailsWithoutRecording(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := os.WriteFile(path, []byte(`{"interactions": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewPropelClient("client-id", "client-secret", "test", ClientOptions{Cassette: cassette})
+
+	var noInteraction *noRecordedInteractionError
+	if !errors.As(err, &noInteraction) {
+		t.Fatalf("expected a missing interaction error, got %v", err)
+	}
+}
@@ -1,16 +1,78 @@
 package client
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
 )
 
 const (
-	apiURL   = "https://api.us-east-2.propeldata.com/graphql"
-	oauthURL = "https://auth.us-east-2.propeldata.com/oauth2/token"
+	apiURLFormat   = "https://api.%s.propeldata.com/graphql"
+	oauthURLFormat = "https://auth.%s.propeldata.com/oauth2/token"
 )
 
+// DefaultRegion is the Propel region used when no region is configured.
+const DefaultRegion = "us_east_2"
+
+// Regions lists the values of the Region enum in the Propel GraphQL schema.
+var Regions = []string{
+	"us_east_2",
+}
+
+// ClientOptions configures where and how the Propel client sends its requests.
+type ClientOptions struct {
+	// Region is the Propel region to use. Defaults to DefaultRegion.
+	Region string
+	// ApiURL overrides the GraphQL API URL derived from the region.
+	ApiURL string
+	// OAuthURL overrides the OAuth token URL derived from the region.
+	OAuthURL string
+	// MaxRetries is the number of times a throttled or failed request is retried. Zero disables retries.
+	MaxRetries int
+	// RetryMaxWait is the longest wait between retries. Defaults to DefaultRetryMaxWait.
+	RetryMaxWait time.Duration
+	// Timeout limits how long a request may take, including retries. Defaults to DefaultTimeout.
+	Timeout time.Duration
+	// ProxyURL is the proxy to send requests through. Defaults to the HTTPS_PROXY environment variable.
+	ProxyURL string
+	// CACertFile is a PEM bundle of certificate authorities to trust in addition to the system roots.
+	CACertFile string
+	// ClientCertFile and ClientKeyFile are a PEM certificate and key to present for mTLS.
+	ClientCertFile string
+	ClientKeyFile  string
+	// Cassette, when set, records the exchanges with the Propel API or replays recorded ones. It is used by tests.
+	Cassette *Cassette
+}
+
+func (o ClientOptions) apiURL() string {
+	if o.ApiURL != "" {
+		return o.ApiURL
+	}
+
+	return fmt.Sprintf(apiURLFormat, o.regionHost())
+}
+
+func (o ClientOptions) oauthURL() string {
+	if o.OAuthURL != "" {
+		return o.OAuthURL
+	}
+
+	return fmt.Sprintf(oauthURLFormat, o.regionHost())
+}
+
+// regionHost converts a Region enum value, such as us_east_2, into the form used in hostnames, such as us-east-2.
+func (o ClientOptions) regionHost() string {
+	region := o.Region
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	return strings.ReplaceAll(region, "_", "-")
+}
+
 type withHeaders struct {
 	headers   map[string]string
 	transport http.RoundTripper
@@ -23,32 +85,44 @@ func (wh *withHeaders) RoundTrip(req *http.Request) (*http.Response, error) {
 	return wh.transport.RoundTrip(req)
 }
 
-// NewAuthenticatedHttpClientWithHeaders returns a new, authenticated HTTP client if the user is authenticated;
-// otherwise, it prompts the user to authenticate before exiting with exit code 1.
+// NewAuthenticatedHttpClientWithHeaders returns a new HTTP client that authenticates every request with a token
+// from the given token source, logging each attempt and retrying throttled and transient failures.
 //
 // Additionally, it allows including default headers.
-func newAuthenticatedHttpClientWithHeaders(headers map[string]string) *http.Client {
-	client := http.DefaultClient
-	client.Transport = &withHeaders{
-		headers:   headers,
-		transport: http.DefaultTransport,
+func newAuthenticatedHttpClientWithHeaders(httpClient *http.Client, tokens *tokenSource, headers map[string]string, opts ClientOptions) *http.Client {
+	return &http.Client{
+		Timeout: httpClient.Timeout,
+		Transport: &withHeaders{
+			headers: headers,
+			transport: newRetryTransport(&withLogging{
+				transport: &withToken{
+					tokens:    tokens,
+					transport: httpClient.Transport,
+				},
+			}, opts.MaxRetries, opts.RetryMaxWait),
+		},
 	}
-	return client
 }
 
-func NewPropelClient(clientId string, secret string, userAgent string) (graphql.Client, error) {
-	token, err := getToken(oauthURL, clientId, secret)
+func NewPropelClient(clientId string, secret string, userAgent string, opts ClientOptions) (graphql.Client, error) {
+	httpClient, err := newHttpClient(opts)
 	if err != nil {
 		return nil, err
 	}
 
-	httpClient := newAuthenticatedHttpClientWithHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"User-Agent":    userAgent,
-	})
-	gqlClient := graphql.NewClient(apiURL, httpClient)
+	tokens := newTokenSource(httpClient, opts.oauthURL(), clientId, secret)
+
+	// Fetch the first token up front so that invalid credentials fail when the provider is configured.
+	if _, err := tokens.Token(); err != nil {
+		return nil, err
+	}
+
+	authenticatedClient := newAuthenticatedHttpClientWithHeaders(httpClient, tokens, map[string]string{
+		"User-Agent": userAgent,
+	}, opts)
+	gqlClient := graphql.NewClient(opts.apiURL(), authenticatedClient)
 
-	return gqlClient, nil
+	return &errorClient{client: gqlClient}, nil
 }
 
 //go:generate go run github.com/Khan/genqlient genqlient.yaml
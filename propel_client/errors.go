@@ -0,0 +1,210 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ErrorKind classifies the errors returned by the Propel API.
+type ErrorKind string
+
+const (
+	ErrorKindUnknown      ErrorKind = "UNKNOWN"
+	ErrorKindNotFound     ErrorKind = "NOT_FOUND"
+	ErrorKindUnauthorized ErrorKind = "UNAUTHORIZED"
+	ErrorKindValidation   ErrorKind = "VALIDATION"
+	ErrorKindConflict     ErrorKind = "CONFLICT"
+	ErrorKindRateLimited  ErrorKind = "RATE_LIMITED"
+)
+
+// Error is an error returned by the Propel API, either as a GraphQL error or as a FailureResponse.
+type Error struct {
+	Kind ErrorKind
+	// Code is the error code reported by the API, if any.
+	Code string
+	// Message is the error message reported by the API.
+	Message string
+	// Field is the input field that the API rejected, if it reported one.
+	Field string
+
+	err error
+}
+
+func (e *Error) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("%s (code: %s)", e.Message, e.Code)
+	}
+
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// IsNotFound reports whether err is a Propel API error for an object that does not exist.
+func IsNotFound(err error) bool {
+	return isKind(err, ErrorKindNotFound)
+}
+
+// IsUnauthorized reports whether err is a Propel API error for missing or insufficient credentials.
+func IsUnauthorized(err error) bool {
+	return isKind(err, ErrorKindUnauthorized)
+}
+
+// IsValidation reports whether err is a Propel API error for invalid input.
+func IsValidation(err error) bool {
+	return isKind(err, ErrorKindValidation)
+}
+
+// IsConflict reports whether err is a Propel API error for a conflict with an existing object.
+func IsConflict(err error) bool {
+	return isKind(err, ErrorKindConflict)
+}
+
+// IsRateLimited reports whether err is a Propel API error for a throttled request.
+func IsRateLimited(err error) bool {
+	return isKind(err, ErrorKindRateLimited)
+}
+
+func isKind(err error, kind ErrorKind) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Kind == kind
+}
+
+// NewFailureError returns the error described by a FailureResponse.
+func NewFailureError(code *int, message string) *Error {
+	apiErr := &Error{
+		Kind:    ErrorKindUnknown,
+		Message: message,
+	}
+
+	if code != nil {
+		apiErr.Code = strconv.Itoa(*code)
+		apiErr.Kind = kindFromStatus(*code)
+	} else {
+		apiErr.Kind = kindFromMessage(message)
+	}
+
+	return apiErr
+}
+
+// errorClient is a graphql.Client that converts the errors returned by the Propel API into *Error.
+type errorClient struct {
+	client graphql.Client
+}
+
+func (ec *errorClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	return parseError(ec.client.MakeRequest(ctx, req, resp))
+}
+
+// httpStatusErrorRegexp matches the error genqlient returns for a non-200 HTTP response.
+var httpStatusErrorRegexp = regexp.MustCompile(`^returned error (\d{3})`)
+
+func parseError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var gqlErrors gqlerror.List
+	if errors.As(err, &gqlErrors) && len(gqlErrors) > 0 {
+		return newGraphQLError(gqlErrors[0], err)
+	}
+
+	if match := httpStatusErrorRegexp.FindStringSubmatch(err.Error()); match != nil {
+		status, _ := strconv.Atoi(match[1])
+
+		return &Error{
+			Kind:    kindFromStatus(status),
+			Code:    match[1],
+			Message: err.Error(),
+			err:     err,
+		}
+	}
+
+	return err
+}
+
+func newGraphQLError(gqlErr *gqlerror.Error, err error) *Error {
+	apiErr := &Error{
+		Kind:    ErrorKindUnknown,
+		Message: gqlErr.Message,
+		err:     err,
+	}
+
+	if code, ok := gqlErr.Extensions["code"]; ok {
+		apiErr.Code = fmt.Sprintf("%v", code)
+		apiErr.Kind = kindFromCode(apiErr.Code)
+	} else {
+		apiErr.Kind = kindFromMessage(gqlErr.Message)
+	}
+
+	for _, key := range []string{"field", "argumentName"} {
+		if field, ok := gqlErr.Extensions[key].(string); ok {
+			apiErr.Field = field
+			break
+		}
+	}
+
+	return apiErr
+}
+
+func kindFromCode(code string) ErrorKind {
+	if status, err := strconv.Atoi(code); err == nil {
+		return kindFromStatus(status)
+	}
+
+	switch strings.ToUpper(code) {
+	case "NOT_FOUND":
+		return ErrorKindNotFound
+	case "UNAUTHENTICATED", "UNAUTHORIZED", "FORBIDDEN":
+		return ErrorKindUnauthorized
+	case "BAD_USER_INPUT", "GRAPHQL_VALIDATION_FAILED", "VALIDATION_ERROR", "INVALID_INPUT":
+		return ErrorKindValidation
+	case "CONFLICT", "ALREADY_EXISTS":
+		return ErrorKindConflict
+	case "RATE_LIMITED", "TOO_MANY_REQUESTS", "THROTTLED":
+		return ErrorKindRateLimited
+	}
+
+	return ErrorKindUnknown
+}
+
+func kindFromStatus(status int) ErrorKind {
+	switch status {
+	case 404:
+		return ErrorKindNotFound
+	case 401, 403:
+		return ErrorKindUnauthorized
+	case 400, 422:
+		return ErrorKindValidation
+	case 409:
+		return ErrorKindConflict
+	case 429:
+		return ErrorKindRateLimited
+	}
+
+	return ErrorKindUnknown
+}
+
+// kindFromMessage classifies errors that the API reports without a code. Errors with a code are classified by their
+// code alone.
+func kindFromMessage(message string) ErrorKind {
+	message = strings.ToLower(message)
+
+	switch {
+	case strings.Contains(message, "not found"):
+		return ErrorKindNotFound
+	case strings.Contains(message, "already exists"):
+		return ErrorKindConflict
+	}
+
+	return ErrorKindUnknown
+}
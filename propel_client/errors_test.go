@@ -0,0 +1,86 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestParseError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		kind ErrorKind
+		code string
+	}{
+		{
+			name: "extension code",
+			err:  gqlerror.List{{Message: "Invalid unique name", Extensions: map[string]interface{}{"code": "BAD_USER_INPUT", "field": "uniqueName"}}},
+			kind: ErrorKindValidation,
+			code: "BAD_USER_INPUT",
+		},
+		{
+			name: "numeric extension code",
+			err:  gqlerror.List{{Message: "Slow down", Extensions: map[string]interface{}{"code": 429}}},
+			kind: ErrorKindRateLimited,
+			code: "429",
+		},
+		{
+			name: "message without code",
+			err:  gqlerror.List{{Message: "Data Pool not found"}},
+			kind: ErrorKindNotFound,
+		},
+		{
+			name: "message with unknown code",
+			err:  gqlerror.List{{Message: "Upstream table not found", Extensions: map[string]interface{}{"code": "INTERNAL_SERVER_ERROR"}}},
+			kind: ErrorKindUnknown,
+			code: "INTERNAL_SERVER_ERROR",
+		},
+		{
+			name: "HTTP status",
+			err:  fmt.Errorf("returned error 401 Unauthorized: {}"),
+			kind: ErrorKindUnauthorized,
+			code: "401",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var apiErr *Error
+			if !errors.As(parseError(tc.err), &apiErr) {
+				t.Fatalf("expected an *Error, got %T", parseError(tc.err))
+			}
+
+			if apiErr.Kind != tc.kind {
+				t.Fatalf("expected kind %s, got %s", tc.kind, apiErr.Kind)
+			}
+
+			if apiErr.Code != tc.code {
+				t.Fatalf("expected code %q, got %q", tc.code, apiErr.Code)
+			}
+		})
+	}
+}
+
+func TestParseErrorKeepsOtherErrors(t *testing.T) {
+	err := errors.New("connection refused")
+
+	if parseError(err) != err {
+		t.Fatal("expected errors that did not come from the API to be returned unchanged")
+	}
+}
+
+func TestNewFailureError(t *testing.T) {
+	code := 409
+
+	err := fmt.Errorf("wrapped: %w", NewFailureError(&code, "A Data Source with that unique name already exists"))
+	if !IsConflict(err) {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+
+	if IsNotFound(err) {
+		t.Fatal("did not expect a not found error")
+	}
+}
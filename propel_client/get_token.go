@@ -11,9 +11,11 @@ import (
 
 type credentials struct {
 	AccessToken string `json:"access_token"`
+	// ExpiresIn is the lifetime of the access token in seconds.
+	ExpiresIn int `json:"expires_in"`
 }
 
-func getToken(oauthUrl string, clientId string, secret string) (string, error) {
+func getToken(client *http.Client, oauthUrl string, clientId string, secret string) (*credentials, error) {
 	var credentials credentials
 
 	payload := url.Values{}
@@ -21,18 +23,16 @@ func getToken(oauthUrl string, clientId string, secret string) (string, error) {
 	payload.Set("client_id", clientId)
 	payload.Set("client_secret", secret)
 
-	client := &http.Client{}
-
 	req, err := http.NewRequest(http.MethodPost, oauthUrl, strings.NewReader(payload.Encode()))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	defer resp.Body.Close()
@@ -40,17 +40,17 @@ func getToken(oauthUrl string, clientId string, secret string) (string, error) {
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		bodyString := string(bodyBytes)
 
-		return "", fmt.Errorf("Unable to generate Access Token (%d): %s\n\n", resp.StatusCode, bodyString)
+		return nil, fmt.Errorf("Unable to generate Access Token (%d): %s\n\n", resp.StatusCode, bodyString)
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&credentials); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return credentials.AccessToken, nil
+	return &credentials, nil
 }
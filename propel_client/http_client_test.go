@@ -0,0 +1,59 @@
+package client
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHttpClientTrustsCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caCertFile, caCert, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	untrusted, err := newHttpClient(ClientOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := untrusted.Get(server.URL); err == nil {
+		t.Fatal("expected the server certificate to be untrusted without the CA bundle")
+	}
+
+	trusted, err := newHttpClient(ClientOptions{CACertFile: caCertFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := trusted.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
+
+func TestHttpClientRequiresClientCertAndKey(t *testing.T) {
+	if _, err := newHttpClient(ClientOptions{ClientCertFile: "client.pem"}); err == nil {
+		t.Fatal("expected an error when the client key is missing")
+	}
+}
+
+func TestHttpClientDoesNotModifyDefaultClient(t *testing.T) {
+	if _, err := NewPropelClient("id", "secret", "test", ClientOptions{OAuthURL: "http://127.0.0.1:0"}); err == nil {
+		t.Fatal("expected an error from an unreachable OAuth endpoint")
+	}
+
+	if http.DefaultClient.Transport != nil {
+		t.Fatal("expected http.DefaultClient to be left unchanged")
+	}
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redacted = "REDACTED"
+
+// sensitiveKeys lists the lower-cased variable names whose values are never logged.
+var sensitiveKeys = map[string]bool{
+	"password":           true,
+	"secret":             true,
+	"clientsecret":       true,
+	"client_secret":      true,
+	"credentialsjson":    true,
+	"awsaccesskeyid":     true,
+	"awssecretaccesskey": true,
+	"accesstoken":        true,
+	"access_token":       true,
+	"token":              true,
+	"authorization":      true,
+}
+
+var bearerTokenRegexp = regexp.MustCompile(`(?i)bearer\s+[a-z0-9\-._~+/]+=*`)
+
+// withLogging is an http.RoundTripper that logs each GraphQL operation through tflog. Operation names, HTTP statuses,
+// latencies and GraphQL errors are logged at debug level, and variables at trace level after redacting secrets.
+type withLogging struct {
+	transport http.RoundTripper
+}
+
+type graphQLRequest struct {
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type graphQLResponse struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func (wl *withLogging) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	var operation graphQLRequest
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			_ = json.NewDecoder(body).Decode(&operation)
+			body.Close()
+		}
+	}
+
+	fields := map[string]interface{}{
+		"operation": operation.OperationName,
+	}
+
+	if variables, err := json.Marshal(redactVariables(operation.Variables)); err == nil {
+		tflog.Trace(ctx, "Sending Propel API request", map[string]interface{}{
+			"operation": operation.OperationName,
+			"variables": string(variables),
+		})
+	}
+
+	start := time.Now()
+	resp, err := wl.transport.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = redactSecrets(err.Error())
+		tflog.Debug(ctx, "Propel API request failed", fields)
+
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if readErr != nil {
+		return resp, readErr
+	}
+
+	var response graphQLResponse
+	if json.Unmarshal(body, &response) == nil && len(response.Errors) > 0 {
+		errors := make([]string, 0, len(response.Errors))
+		for _, e := range response.Errors {
+			errors = append(errors, redactSecrets(e.Message))
+		}
+
+		fields["graphql_errors"] = strings.Join(errors, "; ")
+	}
+
+	tflog.Debug(ctx, "Received Propel API response", fields)
+
+	return resp, nil
+}
+
+// redactVariables returns a copy of the GraphQL variables with the values of sensitive keys replaced.
+func redactVariables(variables map[string]interface{}) map[string]interface{} {
+	if variables == nil {
+		return nil
+	}
+
+	return redactValue(variables).(map[string]interface{})
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redactedMap := make(map[string]interface{}, len(v))
+		for key, inner := range v {
+			if sensitiveKeys[strings.ToLower(key)] && inner != nil {
+				redactedMap[key] = redacted
+				continue
+			}
+
+			redactedMap[key] = redactValue(inner)
+		}
+
+		return redactedMap
+	case []interface{}:
+		redactedList := make([]interface{}, 0, len(v))
+		for _, inner := range v {
+			redactedList = append(redactedList, redactValue(inner))
+		}
+
+		return redactedList
+	case string:
+		return redactSecrets(v)
+	}
+
+	return value
+}
+
+// redactSecrets replaces bearer tokens in a message so that it can be safely logged.
+func redactSecrets(message string) string {
+	return bearerTokenRegexp.ReplaceAllString(message, "Bearer "+redacted)
+}
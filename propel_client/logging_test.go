@@ -0,0 +1,69 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRedactVariables(t *testing.T) {
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"uniqueName": "My Data Source",
+			"connectionSettings": map[string]interface{}{
+				"username":           "propel",
+				"password":           "hunter2",
+				"credentialsJson":    `{"private_key": "secret"}`,
+				"awsAccessKeyId":     "AKIA0000",
+				"awsSecretAccessKey": "secret",
+			},
+			"tables": []interface{}{
+				map[string]interface{}{"name": "Authorization: Bearer abc.def"},
+			},
+		},
+	}
+
+	out, err := json.Marshal(redactVariables(variables))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{"hunter2", "private_key", "AKIA0000", "abc.def"} {
+		if strings.Contains(string(out), secret) {
+			t.Fatalf("expected %q to be redacted from %s", secret, out)
+		}
+	}
+
+	for _, visible := range []string{"My Data Source", "propel"} {
+		if !strings.Contains(string(out), visible) {
+			t.Fatalf("expected %q to be logged in %s", visible, out)
+		}
+	}
+}
+
+func TestWithLoggingPreservesResponseBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errors":[{"message":"not found"}]}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &withLogging{transport: http.DefaultTransport}}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"operationName":"DataPool","variables":{"id":"DPO"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(body) != `{"errors":[{"message":"not found"}]}` {
+		t.Fatalf("unexpected body %s", body)
+	}
+}
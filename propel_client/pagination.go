@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// PageSize is the number of nodes requested per page when following a connection's cursors.
+const PageSize = 100
+
+// DataSourceTable is a Data Source's table along with all of its columns.
+type DataSourceTable struct {
+	Id      string
+	Name    string
+	Columns []*ColumnData
+}
+
+// FetchDataSourceTables returns all the tables of the Data Source with the given ID, along with all of their columns.
+// Unlike the tables in DataSourceData, which only has the first page of each, it follows the cursors of both the
+// tables and the columns connections. It returns no tables if the Data Source has none yet.
+func FetchDataSourceTables(ctx context.Context, client graphql.Client, id string) ([]*DataSourceTable, error) {
+	first := PageSize
+	var after *string
+
+	tables := make([]*DataSourceTable, 0)
+
+	for {
+		response, err := DataSourceTables(ctx, client, id, &first, after)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.DataSource == nil || response.DataSource.Tables == nil {
+			return tables, nil
+		}
+
+		for _, node := range response.DataSource.Tables.Nodes {
+			table := &DataSourceTable{
+				Id:      node.Id,
+				Name:    node.Name,
+				Columns: make([]*ColumnData, 0),
+			}
+
+			if node.Columns != nil {
+				for _, column := range node.Columns.Nodes {
+					table.Columns = append(table.Columns, &column.ColumnData)
+				}
+
+				if pageInfo := node.Columns.PageInfo; pageInfo != nil && pageInfo.HasNextPage && pageInfo.EndCursor != nil {
+					columns, err := fetchTableColumns(ctx, client, node.Id, pageInfo.EndCursor)
+					if err != nil {
+						return nil, err
+					}
+
+					table.Columns = append(table.Columns, columns...)
+				}
+			}
+
+			tables = append(tables, table)
+		}
+
+		pageInfo := response.DataSource.Tables.PageInfo
+		if pageInfo == nil || !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			return tables, nil
+		}
+
+		after = pageInfo.EndCursor
+	}
+}
+
+// fetchTableColumns returns the columns of the table with the given ID that come after the given cursor.
+func fetchTableColumns(ctx context.Context, client graphql.Client, id string, after *string) ([]*ColumnData, error) {
+	first := PageSize
+
+	columns := make([]*ColumnData, 0)
+
+	for {
+		response, err := TableColumns(ctx, client, id, &first, after)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.Table == nil || response.Table.Columns == nil {
+			return columns, nil
+		}
+
+		for _, column := range response.Table.Columns.Nodes {
+			columns = append(columns, &column.ColumnData)
+		}
+
+		pageInfo := response.Table.Columns.PageInfo
+		if pageInfo == nil || !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			return columns, nil
+		}
+
+		after = pageInfo.EndCursor
+	}
+}
+
+// FetchDataPoolColumns returns all the columns of the Data Pool with the given ID, following the cursors of its
+// columns connection.
+func FetchDataPoolColumns(ctx context.Context, client graphql.Client, id string) ([]*DataPoolColumnData, error) {
+	first := PageSize
+	var after *string
+
+	columns := make([]*DataPoolColumnData, 0)
+
+	for {
+		response, err := DataPoolColumns(ctx, client, id, &first, after)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.DataPool == nil || response.DataPool.Columns == nil {
+			return columns, nil
+		}
+
+		for _, column := range response.DataPool.Columns.Nodes {
+			columns = append(columns, &column.DataPoolColumnData)
+		}
+
+		pageInfo := response.DataPool.Columns.PageInfo
+		if pageInfo == nil || !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			return columns, nil
+		}
+
+		after = pageInfo.EndCursor
+	}
+}
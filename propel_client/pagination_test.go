@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchDataSourceTablesFollowsCursors(t *testing.T) {
+	pages := map[string]string{
+		"DataSourceTables:": `{"dataSource": {"tables": {
+			"pageInfo": {"endCursor": "t1", "hasNextPage": true},
+			"nodes": [{"id": "TBL1", "name": "events", "columns": {
+				"pageInfo": {"endCursor": "c1", "hasNextPage": true},
+				"nodes": [{"name": "timestamp_tz", "type": "TIMESTAMP", "isNullable": false}]
+			}}]
+		}}}`,
+		"DataSourceTables:t1": `{"dataSource": {"tables": {
+			"pageInfo": {"endCursor": "t2", "hasNextPage": false},
+			"nodes": [{"id": "TBL2", "name": "accounts", "columns": {
+				"pageInfo": {"endCursor": "c1", "hasNextPage": false},
+				"nodes": [{"name": "name", "type": "STRING", "isNullable": true}]
+			}}]
+		}}}`,
+		"TableColumns:c1": `{"table": {"columns": {
+			"pageInfo": {"endCursor": "c2", "hasNextPage": true},
+			"nodes": [{"name": "account_id", "type": "STRING", "isNullable": false}]
+		}}}`,
+		"TableColumns:c2": `{"table": {"columns": {
+			"pageInfo": {"endCursor": "c3", "hasNextPage": false},
+			"nodes": [{"name": "value", "type": "INT64", "isNullable": true}]
+		}}}`,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "token", "expires_in": 3600}`))
+	})
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			OperationName string `json:"operationName"`
+			Variables     struct {
+				After *string `json:"after"`
+			} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		key := request.OperationName + ":"
+		if request.Variables.After != nil {
+			key += *request.Variables.After
+		}
+
+		page, ok := pages[key]
+		if !ok {
+			t.Errorf("unexpected request %s", key)
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": ` + page + `}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewPropelClient("client-id", "client-secret", "test", ClientOptions{
+		ApiURL:   server.URL + "/graphql",
+		OAuthURL: server.URL + "/oauth2/token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tables, err := FetchDataSourceTables(context.Background(), client, "DSO1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(tables))
+	}
+
+	var columns []string
+	for _, column := range tables[0].Columns {
+		columns = append(columns, column.Name)
+	}
+
+	if len(columns) != 3 || columns[0] != "timestamp_tz" || columns[1] != "account_id" || columns[2] != "value" {
+		t.Fatalf("unexpected columns %v", columns)
+	}
+
+	if tables[1].Name != "accounts" || len(tables[1].Columns) != 1 {
+		t.Fatalf("unexpected table %+v", tables[1])
+	}
+}
@@ -0,0 +1,206 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a request is retried when no limit is configured.
+	DefaultMaxRetries = 4
+	// DefaultRetryMaxWait is the longest wait between retries when no limit is configured.
+	DefaultRetryMaxWait = 30 * time.Second
+
+	retryMinWait = time.Second
+)
+
+// withRetries is an http.RoundTripper that retries throttled and transient failures with exponential backoff
+// and jitter, honouring the Retry-After header.
+//
+// Mutations are only retried when they were throttled, since the API rejects those before processing them;
+// any other failure may have been applied, so retrying it is not safe.
+type withRetries struct {
+	transport  http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+
+	// sleep is overridden in tests.
+	sleep func(req *http.Request, d time.Duration) error
+}
+
+func newRetryTransport(transport http.RoundTripper, maxRetries int, maxWait time.Duration) *withRetries {
+	if maxWait <= 0 {
+		maxWait = DefaultRetryMaxWait
+	}
+
+	return &withRetries{
+		transport:  transport,
+		maxRetries: maxRetries,
+		maxWait:    maxWait,
+		sleep:      sleepContext,
+	}
+}
+
+func (wr *withRetries) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Without a way to rewind the body, the request can only be sent once.
+	if wr.maxRetries <= 0 || (req.Body != nil && req.GetBody == nil) {
+		return wr.transport.RoundTrip(req)
+	}
+
+	idempotent := isGraphQLQuery(req)
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := wr.transport.RoundTrip(attemptReq)
+		if attempt >= wr.maxRetries || !shouldRetry(req, resp, err, idempotent) {
+			return resp, err
+		}
+
+		wait := wr.backoff(attempt, resp)
+
+		if resp != nil {
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := wr.sleep(req, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns how long to wait before the next attempt. It uses the Retry-After header when the API sends one,
+// and exponential backoff with jitter otherwise.
+func (wr *withRetries) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > wr.maxWait {
+				return wr.maxWait
+			}
+
+			return wait
+		}
+	}
+
+	wait := retryMinWait << uint(attempt)
+	if wait <= 0 || wait > wr.maxWait {
+		wait = wr.maxWait
+	}
+
+	// Wait between half and all of the backoff, so that parallel requests do not retry in lockstep.
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error, idempotent bool) bool {
+	if err != nil {
+		// A cancelled or timed out apply should stop immediately.
+		if req.Context().Err() != nil {
+			return false
+		}
+
+		// A replayed request that was never recorded will not be recorded on the next attempt either.
+		var noInteraction *noRecordedInteractionError
+		if errors.As(err, &noInteraction) {
+			return false
+		}
+
+		return idempotent
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// isGraphQLQuery reports whether the request is a GraphQL query, as opposed to a mutation, and so is safe to retry.
+func isGraphQLQuery(req *http.Request) bool {
+	if req.GetBody == nil {
+		return false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	defer body.Close()
+
+	var payload struct {
+		Query string `json:"query"`
+	}
+
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		return false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewBufferString(payload.Query))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip blank lines and comments, such as genqlient directives, before the operation.
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		return strings.HasPrefix(line, "query") || strings.HasPrefix(line, "{")
+	}
+
+	return false
+}
+
+func sleepContext(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestRetryClient(maxRetries int, waits *[]time.Duration) *http.Client {
+	transport := newRetryTransport(http.DefaultTransport, maxRetries, 10*time.Second)
+	transport.sleep = func(req *http.Request, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+
+	return &http.Client{Transport: transport}
+}
+
+func newTestStatusServer(t *testing.T, statuses []int, header http.Header) (*httptest.Server, *int32) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+
+		if int(n) <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func TestRetryRetriesQueries(t *testing.T) {
+	server, calls := newTestStatusServer(t, []int{http.StatusBadGateway, http.StatusServiceUnavailable}, nil)
+
+	var waits []time.Duration
+	client := newTestRetryClient(4, &waits)
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"query":"\nquery DataPool ($id: ID!) {}"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	if n := atomic.LoadInt32(calls); n != 3 {
+		t.Fatalf("expected 3 attempts, got %d", n)
+	}
+
+	if waits[0] < retryMinWait/2 || waits[0] > retryMinWait || waits[1] < retryMinWait || waits[1] > 2*retryMinWait {
+		t.Fatalf("unexpected backoff %v", waits)
+	}
+}
+
+func TestRetryDoesNotRetryFailedMutations(t *testing.T) {
+	server, calls := newTestStatusServer(t, []int{http.StatusBadGateway}, nil)
+
+	var waits []time.Duration
+	client := newTestRetryClient(4, &waits)
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"query":"\nmutation DeleteDataPool ($id: ID!) {}"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected status 502, got %d", resp.StatusCode)
+	}
+
+	if n := atomic.LoadInt32(calls); n != 1 {
+		t.Fatalf("expected 1 attempt, got %d", n)
+	}
+}
+
+func TestRetryHonoursRetryAfterForThrottledMutations(t *testing.T) {
+	server, calls := newTestStatusServer(t, []int{http.StatusTooManyRequests}, http.Header{"Retry-After": []string{"7"}})
+
+	var waits []time.Duration
+	client := newTestRetryClient(4, &waits)
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"query":"\nmutation DeleteDataPool ($id: ID!) {}"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if n := atomic.LoadInt32(calls); n != 2 {
+		t.Fatalf("expected 2 attempts, got %d", n)
+	}
+
+	if len(waits) != 1 || waits[0] != 7*time.Second {
+		t.Fatalf("expected to wait 7s, got %v", waits)
+	}
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	server, calls := newTestStatusServer(t, []int{429, 429, 429, 429}, nil)
+
+	var waits []time.Duration
+	client := newTestRetryClient(2, &waits)
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"query":"query Metrics {}"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", resp.StatusCode)
+	}
+
+	if n := atomic.LoadInt32(calls); n != 3 {
+		t.Fatalf("expected 3 attempts, got %d", n)
+	}
+}
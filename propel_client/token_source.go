@@ -0,0 +1,134 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// tokenRefreshWindow is how long before its expiry an access token is refreshed.
+const tokenRefreshWindow = time.Minute
+
+// tokenSource fetches access tokens from the OAuth endpoint and caches them until they are about to expire.
+// It is safe for concurrent use.
+type tokenSource struct {
+	client   *http.Client
+	oauthURL string
+	clientId string
+	secret   string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+
+	// now is overridden in tests.
+	now func() time.Time
+}
+
+func newTokenSource(client *http.Client, oauthURL string, clientId string, secret string) *tokenSource {
+	return &tokenSource{
+		client:   client,
+		oauthURL: oauthURL,
+		clientId: clientId,
+		secret:   secret,
+		now:      time.Now,
+	}
+}
+
+// Token returns the cached access token, fetching a new one if there is none or it is about to expire.
+func (ts *tokenSource) Token() (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != "" && (ts.expiresAt.IsZero() || ts.now().Before(ts.expiresAt)) {
+		return ts.token, nil
+	}
+
+	credentials, err := getToken(ts.client, ts.oauthURL, ts.clientId, ts.secret)
+	if err != nil {
+		return "", err
+	}
+
+	ts.token = credentials.AccessToken
+	ts.expiresAt = time.Time{}
+
+	if credentials.ExpiresIn > 0 {
+		lifetime := time.Duration(credentials.ExpiresIn) * time.Second
+
+		// Refresh short-lived tokens halfway through their lifetime instead.
+		refreshWindow := tokenRefreshWindow
+		if lifetime <= 2*refreshWindow {
+			refreshWindow = lifetime / 2
+		}
+
+		ts.expiresAt = ts.now().Add(lifetime - refreshWindow)
+	}
+
+	return ts.token, nil
+}
+
+// invalidate drops the cached access token if it is still the given one, so that the next call to Token
+// re-authenticates. Comparing against the rejected token stops concurrent callers from discarding a token
+// that another caller has just refreshed.
+func (ts *tokenSource) invalidate(token string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token == token {
+		ts.token = ""
+		ts.expiresAt = time.Time{}
+	}
+}
+
+// withToken is an http.RoundTripper that authenticates requests with a token from a tokenSource.
+// If the API rejects the token with a 401, it re-authenticates and retries the request once.
+type withToken struct {
+	tokens    *tokenSource
+	transport http.RoundTripper
+}
+
+func (wt *withToken) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := wt.tokens.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := wt.transport.RoundTrip(authorize(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The request body has already been consumed, so it can only be retried if it can be rewound.
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	wt.tokens.invalidate(token)
+
+	token, err = wt.tokens.Token()
+	if err != nil {
+		return resp, nil
+	}
+
+	retry := authorize(req, token)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+
+		retry.Body = body
+	}
+
+	resp.Body.Close()
+
+	return wt.transport.RoundTrip(retry)
+}
+
+// authorize returns a copy of the request with the given access token, since a RoundTripper must not modify its request.
+func authorize(req *http.Request, token string) *http.Request {
+	authorized := req.Clone(req.Context())
+	authorized.Header.Set("Authorization", "Bearer "+token)
+
+	return authorized
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestOAuthServer(t *testing.T, expiresIn int) (*httptest.Server, *int32) {
+	var issued int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&issued, 1)
+		fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":%d}`, n, expiresIn)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &issued
+}
+
+func TestTokenSourceCachesToken(t *testing.T) {
+	server, issued := newTestOAuthServer(t, 3600)
+	ts := newTokenSource(server.Client(), server.URL, "id", "secret")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := ts.Token(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(issued); n != 1 {
+		t.Fatalf("expected 1 token to be issued, got %d", n)
+	}
+}
+
+func TestTokenSourceRefreshesBeforeExpiry(t *testing.T) {
+	server, _ := newTestOAuthServer(t, 3600)
+	ts := newTokenSource(server.Client(), server.URL, "id", "secret")
+
+	now := time.Now()
+	ts.now = func() time.Time { return now }
+
+	first, err := ts.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(time.Hour - tokenRefreshWindow - time.Second)
+	if token, _ := ts.Token(); token != first {
+		t.Fatalf("expected cached token %q, got %q", first, token)
+	}
+
+	now = now.Add(2 * time.Second)
+	if token, _ := ts.Token(); token == first {
+		t.Fatalf("expected token %q to be refreshed", first)
+	}
+}
+
+func TestWithTokenRetriesOnceOnUnauthorized(t *testing.T) {
+	server, issued := newTestOAuthServer(t, 3600)
+	ts := newTokenSource(server.Client(), server.URL, "id", "secret")
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "query" {
+			t.Errorf("expected the request body to be resent, got %q", body)
+		}
+
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	client := &http.Client{Transport: &withToken{tokens: ts, transport: http.DefaultTransport}}
+
+	resp, err := client.Post(api.URL, "text/plain", strings.NewReader("query"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	if n := atomic.LoadInt32(issued); n != 2 {
+		t.Fatalf("expected 2 tokens to be issued, got %d", n)
+	}
+}